@@ -2,9 +2,10 @@ package apiclient
 
 import (
 	"context"
-	"crypto/tls"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/go-resty/resty/v2"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/eda/rest"
+	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/eda/utils"
 )
 
 const (
@@ -36,11 +38,24 @@ type grant struct {
 	Scope         string  `json:"scope"`
 	TokenType     string  `json:"token_type"`
 	ExpiresInSecs float64 `json:"expires_in"`
-	timestamp     *time.Time
+	// Lifetime of the refresh token, 0 if it does not expire
+	RefreshExpiresInSecs float64 `json:"refresh_expires_in"`
+	timestamp            *time.Time
+}
+
+// Returns true if a token with the given lifetime, issued when the grant was
+// obtained, expires within margin from now. A token with no lifetime never expires.
+func (g *grant) expiresWithin(lifetimeSecs float64, margin time.Duration) bool {
+	if g.timestamp == nil || lifetimeSecs == 0 {
+		return false
+	}
+	expiry := g.timestamp.Add(time.Duration(lifetimeSecs * float64(time.Second)))
+	return time.Until(expiry) <= margin
 }
 
 type clientCredentials struct {
 	authUrl      string
+	grantType    string
 	clientId     string
 	clientSecret string
 	username     string
@@ -54,11 +69,21 @@ type EdaApiClient struct {
 	edaCred       *clientCredentials
 	keyCloakGrant *grant
 	edaGrant      *grant
-	logCtx        context.Context
+	// Batch of writes collected for the next transaction, see Transact
+	txLock  sync.Mutex
+	txBatch *transactionBatch
+	// Limits the rate and the concurrency of the requests
+	throttle *throttle
+	// Base URLs the requests are sent to, see withEndpoint
+	endpoints *endpoints
+	// Keys redacted in the logs of the client, the built-in keys and the configured LogMaskedKeys
+	sensitiveKeys *utils.SensitiveKeySet
 }
 
 type Config struct {
-	BaseURL           string        `json:"baseURL"`
+	BaseURL string `json:"baseURL"`
+	// Other base URLs of the same EDA deployment, failed over to in order when the base URL is unavailable
+	BaseURLs          []string      `json:"baseURLs"`
 	KcUsername        string        `json:"kcUsername"`
 	KcPassword        string        `json:"kcPassword"`
 	KcRealm           string        `json:"kcRealm"`
@@ -73,11 +98,56 @@ type Config struct {
 	RestTimeout       time.Duration `json:"restTimeout"`
 	RestRetries       int           `json:"restRetries"`
 	RestRetryInterval time.Duration `json:"restRetryInterval"`
+	UpdateMethod      string        `json:"updateMethod"`
+	WaitForReady      bool          `json:"waitForReady"`
+	ReadyPollInterval time.Duration `json:"readyPollInterval"`
+	// Status field reporting the state of resources, and its values meaning ready or failed
+	ReadyStatusField   string   `json:"readyStatusField"`
+	ReadyStatusValues  []string `json:"readyStatusValues"`
+	FailedStatusValues []string `json:"failedStatusValues"`
+	// Access tokens are refreshed this long before they expire
+	TokenRefreshMargin time.Duration `json:"tokenRefreshMargin"`
+	// One of AUTH_MODE_PASSWORD, AUTH_MODE_CLIENT_CREDENTIALS or AUTH_MODE_TOKEN
+	AuthMode           string `json:"authMode"`
+	AccessToken        string `json:"accessToken"`
+	AccessTokenFile    string `json:"accessTokenFile"`
+	AccessTokenCommand string `json:"accessTokenCommand"`
+	// Keys masked in logs in addition to the built-in sensitive keys
+	LogMaskedKeys []string `json:"logMaskedKeys"`
+	// PEM encoded CA bundle, trusted in addition to the system roots
+	CaCert     string `json:"caCert"`
+	CaCertFile string `json:"caCertFile"`
+	// PEM encoded client certificate and key for mutual TLS
+	ClientCert    string `json:"clientCert"`
+	ClientKey     string `json:"clientKey"`
+	TlsServerName string `json:"tlsServerName"`
+	// Default query parameters of write requests, see WriteOptions
+	DetailLevel     string `json:"detailLevel"`
+	DisableBatching bool   `json:"disableBatching"`
+	// Writes submitted within the batch window are committed in a single transaction
+	TransactionMode        bool          `json:"transactionMode"`
+	TransactionDryRun      bool          `json:"transactionDryRun"`
+	TransactionBatchWindow time.Duration `json:"transactionBatchWindow"`
+	// Validate planned resources with a dry run transaction
+	PlanValidation bool `json:"planValidation"`
+	// Diagnostics raised on refresh for the active alarms and deviations of resources
+	AlarmWarnings      bool   `json:"alarmWarnings"`
+	AlarmErrorSeverity string `json:"alarmErrorSeverity"`
+	DeviationErrors    bool   `json:"deviationErrors"`
+	// Limits of the rate and the concurrency of API requests, 0 is unlimited
+	MaxRequestsPerSecond  float64 `json:"maxRequestsPerSecond"`
+	MaxConcurrentRequests int     `json:"maxConcurrentRequests"`
+	// HTTP transport settings, see rest.TransportConfig
+	ProxyURL        string        `json:"proxyURL"`
+	NoProxy         string        `json:"noProxy"`
+	MaxIdleConns    int           `json:"maxIdleConns"`
+	IdleConnTimeout time.Duration `json:"idleConnTimeout"`
 }
 
 func (cfg *Config) String() string {
 	sb := strings.Builder{}
 	sb.WriteString(fmt.Sprintf("%s: %s, ", "baseURL", cfg.BaseURL))
+	sb.WriteString(fmt.Sprintf("%s: %v, ", "baseURLs", cfg.BaseURLs))
 	sb.WriteString(fmt.Sprintf("%s: %s, ", "kcUsername", cfg.KcUsername))
 	sb.WriteString(fmt.Sprintf("%s: %s, ", "kcRealm", cfg.KcRealm))
 	sb.WriteString(fmt.Sprintf("%s: %s, ", "kcClientId", cfg.KcClientID))
@@ -88,110 +158,186 @@ func (cfg *Config) String() string {
 	sb.WriteString(fmt.Sprintf("%s: %t, ", "restDebug", cfg.RestDebug))
 	sb.WriteString(fmt.Sprintf("%s: %s, ", "restTimeout", cfg.RestTimeout))
 	sb.WriteString(fmt.Sprintf("%s: %d, ", "restRetries", cfg.RestRetries))
-	sb.WriteString(fmt.Sprintf("%s: %s", "restRetryInterval", cfg.RestRetryInterval))
+	sb.WriteString(fmt.Sprintf("%s: %s, ", "restRetryInterval", cfg.RestRetryInterval))
+	sb.WriteString(fmt.Sprintf("%s: %s, ", "updateMethod", cfg.UpdateMethod))
+	sb.WriteString(fmt.Sprintf("%s: %t, ", "waitForReady", cfg.WaitForReady))
+	sb.WriteString(fmt.Sprintf("%s: %s, ", "readyPollInterval", cfg.ReadyPollInterval))
+	sb.WriteString(fmt.Sprintf("%s: %s, ", "readyStatusField", cfg.ReadyStatusField))
+	sb.WriteString(fmt.Sprintf("%s: %v, ", "readyStatusValues", cfg.ReadyStatusValues))
+	sb.WriteString(fmt.Sprintf("%s: %v, ", "failedStatusValues", cfg.FailedStatusValues))
+	sb.WriteString(fmt.Sprintf("%s: %s, ", "tokenRefreshMargin", cfg.TokenRefreshMargin))
+	sb.WriteString(fmt.Sprintf("%s: %s, ", "authMode", cfg.AuthMode))
+	sb.WriteString(fmt.Sprintf("%s: %s, ", "accessTokenFile", cfg.AccessTokenFile))
+	sb.WriteString(fmt.Sprintf("%s: %s, ", "accessTokenCommand", cfg.AccessTokenCommand))
+	sb.WriteString(fmt.Sprintf("%s: %v, ", "logMaskedKeys", cfg.LogMaskedKeys))
+	sb.WriteString(fmt.Sprintf("%s: %s, ", "caCertFile", cfg.CaCertFile))
+	sb.WriteString(fmt.Sprintf("%s: %t, ", "clientCert", cfg.ClientCert != ""))
+	sb.WriteString(fmt.Sprintf("%s: %s, ", "tlsServerName", cfg.TlsServerName))
+	sb.WriteString(fmt.Sprintf("%s: %s, ", "detailLevel", cfg.DetailLevel))
+	sb.WriteString(fmt.Sprintf("%s: %t, ", "disableBatching", cfg.DisableBatching))
+	sb.WriteString(fmt.Sprintf("%s: %t, ", "transactionMode", cfg.TransactionMode))
+	sb.WriteString(fmt.Sprintf("%s: %t, ", "transactionDryRun", cfg.TransactionDryRun))
+	sb.WriteString(fmt.Sprintf("%s: %s, ", "transactionBatchWindow", cfg.TransactionBatchWindow))
+	sb.WriteString(fmt.Sprintf("%s: %t, ", "planValidation", cfg.PlanValidation))
+	sb.WriteString(fmt.Sprintf("%s: %t, ", "alarmWarnings", cfg.AlarmWarnings))
+	sb.WriteString(fmt.Sprintf("%s: %s, ", "alarmErrorSeverity", cfg.AlarmErrorSeverity))
+	sb.WriteString(fmt.Sprintf("%s: %t, ", "deviationErrors", cfg.DeviationErrors))
+	sb.WriteString(fmt.Sprintf("%s: %g, ", "maxRequestsPerSecond", cfg.MaxRequestsPerSecond))
+	sb.WriteString(fmt.Sprintf("%s: %d, ", "maxConcurrentRequests", cfg.MaxConcurrentRequests))
+	sb.WriteString(fmt.Sprintf("%s: %s, ", "proxyURL", redactURL(cfg.ProxyURL)))
+	sb.WriteString(fmt.Sprintf("%s: %s, ", "noProxy", cfg.NoProxy))
+	sb.WriteString(fmt.Sprintf("%s: %d, ", "maxIdleConns", cfg.MaxIdleConns))
+	sb.WriteString(fmt.Sprintf("%s: %s", "idleConnTimeout", cfg.IdleConnTimeout))
 	return sb.String()
 }
 
-func NewEdaApiClient(logCtx context.Context, cfg *Config) (*EdaApiClient, error) {
+// Returns rawUrl with the password of its user info, if any, redacted
+func redactURL(rawUrl string) string {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return utils.REDACTED
+	}
+	return u.Redacted()
+}
+
+func NewEdaApiClient(ctx context.Context, cfg *Config) (*EdaApiClient, error) {
 	if cfg == nil {
 		return nil, errors.New("config cannot be nil")
 	}
 	client := &EdaApiClient{
 		cfg: cfg,
 		edaCred: &clientCredentials{
-			authUrl:  fmt.Sprintf(OAUTH_URL, cfg.EdaRealm),
-			clientId: cfg.EdaClientID,
-			username: cfg.EdaUsername,
-			password: cfg.EdaPassword,
+			authUrl:   fmt.Sprintf(OAUTH_URL, cfg.EdaRealm),
+			grantType: KEY_PASSWORD_GRANT,
+			clientId:  cfg.EdaClientID,
+			username:  cfg.EdaUsername,
+			password:  cfg.EdaPassword,
 		},
 		keyCloakGrant: &grant{},
 		edaGrant:      &grant{},
-		logCtx:        logCtx,
+		throttle:      newThrottle(cfg.MaxRequestsPerSecond, cfg.MaxConcurrentRequests),
+		endpoints:     newEndpoints(cfg.Endpoints()),
+		sensitiveKeys: utils.NewSensitiveKeySet(cfg.LogMaskedKeys...),
+	}
+	tlsConfig, err := NewTlsConfig(cfg)
+	if err != nil {
+		return nil, err
 	}
-	client.restClient = rest.CreateApiClient().
-		WithBaseURL(cfg.BaseURL).
+	restClient, err := rest.CreateApiClient().
 		WithTimeout(cfg.RestTimeout).
-		WithRetryCount(cfg.RestRetries).
-		WithRetryInterval(cfg.RestRetryInterval).
-		WithTlsConfig(&tls.Config{InsecureSkipVerify: cfg.TlsSkipVerify}).
-		WithDebug(cfg.RestDebug)
+		WithRetryPolicy(rest.RetryPolicy{MaxRetries: cfg.RestRetries, MaxInterval: cfg.RestRetryInterval}).
+		WithTlsConfig(tlsConfig).
+		WithTransportConfig(rest.TransportConfig{
+			ProxyURL:        cfg.ProxyURL,
+			NoProxy:         cfg.NoProxy,
+			MaxIdleConns:    cfg.MaxIdleConns,
+			IdleConnTimeout: cfg.IdleConnTimeout,
+		})
+	if err != nil {
+		return nil, err
+	}
+	client.restClient = restClient.WithDebug(cfg.RestDebug, client.sensitiveKeys)
 
-	if cfg.EdaClientSecret != "" {
+	if cfg.AuthMode == AUTH_MODE_CLIENT_CREDENTIALS {
+		client.edaCred.grantType = KEY_CLIENT_CREDENTIALS_GRANT
+	}
+	// The Keycloak admin lookup of the client secret is only needed for the password grant
+	if cfg.EdaClientSecret != "" || cfg.AuthMode == AUTH_MODE_CLIENT_CREDENTIALS || cfg.AuthMode == AUTH_MODE_TOKEN {
 		client.edaCred.clientSecret = cfg.EdaClientSecret
 		return client, nil
 	}
-	var err error
-	client.edaCred.clientSecret, err = client.getClientSecret(cfg.EdaClientID)
+	client.edaCred.clientSecret, err = client.getClientSecret(ctx, cfg.EdaClientID)
 	if err != nil {
 		return nil, err
 	}
 	return client, nil
 }
 
-func (c *EdaApiClient) getEdaAccessToken() (string, error) {
-	return c.getAccessToken(c.edaCred, c.edaGrant)
+// Config returns the configuration the client was created with
+func (c *EdaApiClient) Config() *Config {
+	return c.cfg
 }
 
-// Attempt login with retries and exponential backoff
-func (c *EdaApiClient) login(authUrl string, oauthBody map[string]string, grnt *grant) error {
-	tflog.Trace(c.logCtx, "login()", map[string]any{"authUrl": authUrl, "oauthBody": fmt.Sprintf("%v", oauthBody)})
-	var resp *resty.Response
-	var err error
-	maxRetries := 5
-	baseDelay := time.Second
-
-	for attempt := range maxRetries {
-		resp, err = c.restClient.DoLogin(authUrl, oauthBody, grnt)
-		if err == nil && !resp.IsError() {
-			timestamp := time.Now()
-			grnt.timestamp = &timestamp
-			tflog.Info(c.logCtx, "login()", map[string]any{"authUrl": authUrl, "status": resp.Status(),
-				"resp": resp.String(), "timeTaken": resp.Time().String()})
-			return nil
-		}
+func (c *EdaApiClient) getEdaAccessToken(ctx context.Context) (string, error) {
+	return c.getAccessToken(ctx, c.edaCred, c.edaGrant)
+}
 
-		// Log the error and response for debugging
-		tflog.Error(c.logCtx, "Login attempt failed", map[string]any{
-			"attempt": attempt + 1,
-			"error":   err,
-			"status":  resp.Status(),
-			"body":    resp.String(),
-		})
+// Logs in to authUrl, storing the tokens issued in grnt. Transient failures are retried
+// with the REST retry policy, rejected credentials fail immediately with a LoginError.
+// Stops retrying as soon as ctx is cancelled or its deadline expires.
+func (c *EdaApiClient) login(ctx context.Context, authUrl string, oauthBody map[string]string, grnt *grant) error {
+	ctx = c.maskSensitiveFields(ctx)
+	tflog.Trace(ctx, "login()", map[string]any{"authUrl": authUrl, "oauthBody": fmt.Sprintf("%v", c.sensitiveKeys.Redact(oauthBody))})
 
-		// Exponential backoff before the next retry
-		if attempt < maxRetries-1 { // Don’t sleep after last attempt
-			time.Sleep(baseDelay * (1 << attempt))
+	// Token requests have no side effects, and can fail over like idempotent requests
+	resp, err := c.withEndpoint(ctx, true, func(ctx context.Context, baseUrl string) (*resty.Response, error) {
+		return c.restClient.DoLogin(ctx, baseUrl+authUrl, oauthBody, grnt)
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("login aborted: %w", ctx.Err())
 		}
+		tflog.Error(ctx, "login()::Login failed", map[string]any{"authUrl": authUrl, "error": err.Error()})
+		return fmt.Errorf("login to %s failed: %w", authUrl, err)
 	}
-
-	if err != nil {
-		return fmt.Errorf("login failed after %d attempts: %w", maxRetries, err)
+	if resp.IsError() {
+		loginErr := newLoginError(authUrl, resp, c.sensitiveKeys)
+		tflog.Error(ctx, "login()::Login failed", map[string]any{
+			"authUrl":          authUrl,
+			"status":           resp.Status(),
+			"error":            loginErr.Code,
+			"errorDescription": loginErr.Description,
+		})
+		return loginErr
 	}
-	return fmt.Errorf("login failed after %d attempts: %s", maxRetries, resp.String())
+
+	timestamp := time.Now()
+	grnt.timestamp = &timestamp
+	tflog.Info(ctx, "login()", map[string]any{"authUrl": authUrl, "status": resp.Status(),
+		"resp": c.sensitiveKeys.RedactString(resp.String()), "timeTaken": resp.Time().String()})
+	return nil
 }
 
-func (c *EdaApiClient) getAccessToken(cred *clientCredentials, grnt *grant) (string, error) {
+// Returns a valid access token for cred, logging in again when the current token
+// expires within the configured refresh margin. The refresh token is used while it
+// is valid, falling back to a password grant when it has expired or the refresh fails.
+func (c *EdaApiClient) getAccessToken(ctx context.Context, cred *clientCredentials, grnt *grant) (string, error) {
 	c.tokenLock.Lock()
 	defer c.tokenLock.Unlock()
 
-	expired := false
-	if grnt.timestamp != nil && grnt.ExpiresInSecs != 0 {
-		elapsed := time.Since(*grnt.timestamp).Seconds()
-		tflog.Debug(c.logCtx, "getAccessToken()", map[string]any{"authUrl": cred.authUrl, "timeElapsed": elapsed})
-		expired = elapsed > grnt.ExpiresInSecs
-	}
-	tflog.Trace(c.logCtx, "getAccessToken()", map[string]any{"authUrl": cred.authUrl,
-		"grantExpired": expired, "accessToken": grnt.AccessToken, "refreshToken": grnt.RefreshToken})
+	margin := c.cfg.TokenRefreshMargin
+	expired := grnt.expiresWithin(grnt.ExpiresInSecs, margin)
+	refreshExpired := grnt.expiresWithin(grnt.RefreshExpiresInSecs, margin)
+	tflog.Debug(ctx, "getAccessToken()", map[string]any{"authUrl": cred.authUrl,
+		"grantExpired": expired, "refreshExpired": refreshExpired})
 
 	if !expired && grnt.AccessToken != "" {
-		tflog.Trace(c.logCtx, "getAccessToken()", map[string]any{"authUrl": cred.authUrl, "existingToken": grnt.AccessToken})
 		return grnt.AccessToken, nil
 	}
+	if cred == c.edaCred && c.cfg.AuthMode == AUTH_MODE_TOKEN {
+		// Pre-issued tokens are used until rejected, then read again from their source
+		token, err := c.readAccessToken(ctx)
+		if err != nil {
+			return "", err
+		}
+		if token == "" {
+			return "", fmt.Errorf("access token is empty")
+		}
+		grnt.AccessToken = token
+		return token, nil
+	}
 	var err error
-	if expired && grnt.RefreshToken != "" {
-		err = c.login(cred.authUrl, c.getOauthBody(cred, grnt.RefreshToken), grnt)
+	if grnt.RefreshToken != "" && !refreshExpired {
+		err = c.login(ctx, cred.authUrl, c.getOauthBody(cred, grnt.RefreshToken), grnt)
+		if err != nil && ctx.Err() == nil {
+			tflog.Warn(ctx, "getAccessToken()::Token refresh failed, logging in again", map[string]any{
+				"authUrl": cred.authUrl,
+				"error":   err.Error(),
+			})
+			err = c.login(ctx, cred.authUrl, c.getOauthBody(cred, ""), grnt)
+		}
 	} else {
-		err = c.login(cred.authUrl, c.getOauthBody(cred, ""), grnt)
+		err = c.login(ctx, cred.authUrl, c.getOauthBody(cred, ""), grnt)
 	}
 	if err != nil {
 		return "", err
@@ -199,10 +345,19 @@ func (c *EdaApiClient) getAccessToken(cred *clientCredentials, grnt *grant) (str
 	if grnt.AccessToken == "" {
 		return "", fmt.Errorf("access token is empty")
 	}
-	tflog.Trace(c.logCtx, "getAccessToken()", map[string]any{"authUrl": cred.authUrl, "newToken": grnt.AccessToken})
 	return grnt.AccessToken, nil
 }
 
+// Discards the access token of grnt if it is still accessToken, so that the next
+// call to getAccessToken authenticates again
+func (c *EdaApiClient) invalidateAccessToken(grnt *grant, accessToken string) {
+	c.tokenLock.Lock()
+	defer c.tokenLock.Unlock()
+	if grnt.AccessToken == accessToken {
+		grnt.AccessToken = ""
+	}
+}
+
 func (c *EdaApiClient) getOauthBody(cred *clientCredentials, refreshToken string) map[string]string {
 	oauthBody := make(map[string]string)
 	oauthBody[KEY_CLIENT_ID] = cred.clientId
@@ -210,6 +365,8 @@ func (c *EdaApiClient) getOauthBody(cred *clientCredentials, refreshToken string
 	if refreshToken != "" {
 		oauthBody[KEY_GRANT_TYPE] = KEY_REFRESH_GRANT
 		oauthBody[KEY_REFRESH_GRANT] = refreshToken
+	} else if cred.grantType == KEY_CLIENT_CREDENTIALS_GRANT {
+		oauthBody[KEY_GRANT_TYPE] = KEY_CLIENT_CREDENTIALS_GRANT
 	} else {
 		oauthBody[KEY_GRANT_TYPE] = KEY_PASSWORD_GRANT
 		oauthBody[KEY_USERNAME] = cred.username
@@ -218,27 +375,30 @@ func (c *EdaApiClient) getOauthBody(cred *clientCredentials, refreshToken string
 	return oauthBody
 }
 
-func (c *EdaApiClient) getClientSecret(id string) (string, error) {
+func (c *EdaApiClient) getClientSecret(ctx context.Context, id string) (string, error) {
 	keyCloakCred := &clientCredentials{
-		authUrl:  fmt.Sprintf(OAUTH_URL, c.cfg.KcRealm),
-		clientId: c.cfg.KcClientID,
-		username: c.cfg.KcUsername,
-		password: c.cfg.KcPassword,
+		authUrl:   fmt.Sprintf(OAUTH_URL, c.cfg.KcRealm),
+		grantType: KEY_PASSWORD_GRANT,
+		clientId:  c.cfg.KcClientID,
+		username:  c.cfg.KcUsername,
+		password:  c.cfg.KcPassword,
 	}
-	accessToken, err := c.getAccessToken(keyCloakCred, c.keyCloakGrant)
+	accessToken, err := c.getAccessToken(ctx, keyCloakCred, c.keyCloakGrant)
 	if err != nil {
 		return "", err
 	}
 
 	result := []map[string]any{}
-	resp, err := c.restClient.DoQuery(accessToken, CLIENT_URL, &result,
-		map[string]string{"realm": c.cfg.EdaRealm},
-		map[string]string{"clientId": id})
+	resp, err := c.withEndpoint(ctx, true, func(ctx context.Context, baseUrl string) (*resty.Response, error) {
+		return c.restClient.DoQuery(ctx, accessToken, baseUrl+CLIENT_URL, &result,
+			map[string]string{"realm": c.cfg.EdaRealm},
+			map[string]string{"clientId": id})
+	})
 	if err != nil {
 		return "", err
 	}
-	tflog.Info(c.logCtx, "getClientSecret()", map[string]any{"url": CLIENT_URL, "status": resp.Status(),
-		"resp": resp.String(), "timeTaken": resp.Time().String()})
+	tflog.Info(ctx, "getClientSecret()", map[string]any{"url": CLIENT_URL, "status": resp.Status(),
+		"resp": c.sensitiveKeys.RedactString(resp.String()), "timeTaken": resp.Time().String()})
 
 	if len(result) == 0 {
 		return "", fmt.Errorf("client not found: %s", id)
@@ -247,12 +407,11 @@ func (c *EdaApiClient) getClientSecret(id string) (string, error) {
 	if !ok {
 		return "", fmt.Errorf("client secret not found for client: %s", id)
 	}
-	tflog.Trace(c.logCtx, "getClientSecret()", map[string]any{"secret": secret})
 	return secret.(string), nil
 }
 
-func (c *EdaApiClient) Create(ctx context.Context, pathUrl string, pathParams map[string]string, body any, result any) error {
-	return c.Execute(ctx, pathUrl, rest.HTTP_POST, pathParams, nil, body, result)
+func (c *EdaApiClient) Create(ctx context.Context, pathUrl string, pathParams map[string]string, opts WriteOptions, body any, result any) error {
+	return c.Execute(ctx, pathUrl, rest.HTTP_POST, pathParams, opts.queryParams(nil), body, result)
 }
 
 func (c *EdaApiClient) Get(ctx context.Context, pathUrl string, pathParams map[string]string, result any) error {
@@ -263,34 +422,122 @@ func (c *EdaApiClient) GetByQuery(ctx context.Context, pathUrl string, pathParam
 	return c.Execute(ctx, pathUrl, rest.HTTP_GET, pathParams, queryParams, nil, result)
 }
 
-func (c *EdaApiClient) Update(ctx context.Context, pathUrl string, pathParams map[string]string, body any, result any) error {
-	return c.Execute(ctx, pathUrl, rest.HTTP_PUT, pathParams, nil, body, result)
+func (c *EdaApiClient) Update(ctx context.Context, pathUrl string, pathParams map[string]string, opts WriteOptions, body any, result any) error {
+	return c.Execute(ctx, pathUrl, rest.HTTP_PUT, pathParams, opts.queryParams(nil), body, result)
+}
+
+// Patch applies a JSON patch (RFC 6902) to the resource at pathUrl
+func (c *EdaApiClient) Patch(ctx context.Context, pathUrl string, pathParams map[string]string, opts WriteOptions, patch []PatchOp, result any) error {
+	return c.execute(ctx, pathUrl, rest.HTTP_PATCH, pathParams, opts.queryParams(nil), map[string]string{
+		"Content-Type": rest.CONTENT_TYPE_JSON_PATCH,
+		"Accept":       rest.CONTENT_TYPE_JSON,
+	}, patch, result)
 }
 
-func (c *EdaApiClient) Delete(ctx context.Context, pathUrl string, pathParams map[string]string, result any) error {
-	return c.Execute(ctx, pathUrl, rest.HTTP_DELETE, pathParams, nil, nil, result)
+func (c *EdaApiClient) Delete(ctx context.Context, pathUrl string, pathParams map[string]string, opts WriteOptions, result any) error {
+	return c.Execute(ctx, pathUrl, rest.HTTP_DELETE, pathParams, opts.queryParams(nil), nil, result)
+}
+
+// DeleteByQuery deletes all the resources of the collection at pathUrl matching the query,
+// e.g. a labelSelector
+func (c *EdaApiClient) DeleteByQuery(ctx context.Context, pathUrl string, pathParams, queryParams map[string]string,
+	opts WriteOptions, result any) error {
+	return c.Execute(ctx, pathUrl, rest.HTTP_DELETE, pathParams, opts.queryParams(queryParams), nil, result)
 }
 
 func (c *EdaApiClient) Execute(ctx context.Context, pathUrl, method string,
 	pathParams, queryParams map[string]string, body, result any) error {
-	accessToken, err := c.getEdaAccessToken()
+	return c.execute(ctx, pathUrl, method, pathParams, queryParams, nil, body, result)
+}
+
+func (c *EdaApiClient) execute(ctx context.Context, pathUrl, method string,
+	pathParams, queryParams, headers map[string]string, body, result any) error {
+	ctx = c.maskSensitiveFields(ctx)
+	t0 := time.Now()
+	release, err := c.throttle.acquire(ctx)
 	if err != nil {
 		return err
 	}
-	tflog.Debug(c.logCtx, "Invoking DoExecute()::"+method+" "+pathUrl, map[string]any{
+	defer release()
+	tflog.Debug(ctx, "execute()::Request dequeued", map[string]any{
+		"method":     method,
+		"path":       pathUrl,
+		"queueDelay": time.Since(t0).String(),
+	})
+	resp, err := c.doExecute(ctx, pathUrl, method, pathParams, queryParams, headers, body, result)
+	if err == nil && resp.StatusCode() == http.StatusUnauthorized {
+		// The token may have been revoked or expired early, authenticate again and retry once
+		tflog.Info(ctx, "execute()::Unauthorized, retrying with a new access token", map[string]any{
+			"method": method,
+			"path":   pathUrl,
+		})
+		c.invalidateAccessToken(c.edaGrant, resp.Request.Token)
+		resp, err = c.doExecute(ctx, pathUrl, method, pathParams, queryParams, headers, body, result)
+	}
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		apiErr := newAPIError(method, pathUrl, resp)
+		if apiErr.Response != nil {
+			tflog.Warn(ctx, "execute()::API error", map[string]any{
+				"method":  method,
+				"path":    pathUrl,
+				"status":  apiErr.Status,
+				"message": apiErr.Summary(),
+				"details": apiErr.Response.Details,
+			})
+		}
+		return apiErr
+	}
+	return nil
+}
+
+func (c *EdaApiClient) doExecute(ctx context.Context, pathUrl, method string,
+	pathParams, queryParams, headers map[string]string, body, result any) (*resty.Response, error) {
+	accessToken, err := c.getEdaAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tflog.Debug(ctx, "Invoking DoExecute()::"+method+" "+pathUrl, map[string]any{
 		"pathParams":  pathParams,
 		"queryParams": queryParams,
 	})
-	resp, err := c.restClient.DoExecute(method, pathUrl, accessToken, body, result, pathParams, queryParams, nil)
+	resp, err := c.withEndpoint(ctx, rest.IsIdempotent(method), func(ctx context.Context, baseUrl string) (*resty.Response, error) {
+		return c.restClient.DoExecute(ctx, method, baseUrl+pathUrl, accessToken, body, result, pathParams, queryParams, headers)
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
-	tflog.Debug(c.logCtx, "After DoExecute()::"+method+" "+pathUrl, map[string]any{
+	tflog.Debug(ctx, "After DoExecute()::"+method+" "+pathUrl, map[string]any{
 		"status":    resp.Status(),
 		"timeTaken": resp.Time().String(),
 	})
-	if resp.IsError() {
-		return fmt.Errorf("%s %s", resp.Status(), resp.String())
+	return resp, nil
+}
+
+// SensitiveKeys returns the keys redacted in the logs of the client, or nil if c is nil
+func (c *EdaApiClient) SensitiveKeys() *utils.SensitiveKeySet {
+	if c == nil {
+		return nil
+	}
+	return c.sensitiveKeys
+}
+
+// Returns a context that masks the values of the sensitive log fields of the client
+func (c *EdaApiClient) maskSensitiveFields(ctx context.Context) context.Context {
+	ctx = utils.WithSensitiveKeys(ctx, c.sensitiveKeys)
+	return tflog.MaskFieldValuesWithFieldKeys(ctx, c.sensitiveKeys.Keys()...)
+}
+
+// Waits for d, or until ctx is done, in which case the context error is returned
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
 	}
-	return nil
 }
@@ -0,0 +1,90 @@
+package apiclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Returns a client of a local fake EDA API whose token endpoint replies with the
+// given responses in turn, then issues a token
+func newTestLoginClient(t *testing.T, tokenResponses []func(w http.ResponseWriter)) (*EdaApiClient, *atomic.Int32) {
+	var logins atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf(OAUTH_URL, "eda"), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if n := int(logins.Add(1)); n <= len(tokenResponses) {
+			tokenResponses[n-1](w)
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token": "test-token", "expires_in": 300}`))
+	})
+	mux.HandleFunc("/apps/vmware.eda.nokia.com", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name": "vmware.eda.nokia.com"}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := NewEdaApiClient(context.Background(), &Config{
+		BaseURL:           server.URL,
+		EdaRealm:          "eda",
+		EdaClientID:       "eda",
+		EdaClientSecret:   "secret",
+		EdaUsername:       "admin",
+		EdaPassword:       "admin",
+		RestTimeout:       5 * time.Second,
+		RestRetries:       3,
+		RestRetryInterval: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, &logins
+}
+
+func TestLoginRetriesTransientErrors(t *testing.T) {
+	client, logins := newTestLoginClient(t, []func(w http.ResponseWriter){
+		func(w http.ResponseWriter) { w.WriteHeader(http.StatusServiceUnavailable) },
+		func(w http.ResponseWriter) { w.WriteHeader(http.StatusBadGateway) },
+	})
+
+	result := map[string]any{}
+	if err := client.Get(context.Background(), "/apps/vmware.eda.nokia.com", nil, &result); err != nil {
+		t.Fatal(err)
+	}
+	if got := logins.Load(); got != 3 {
+		t.Errorf("token requests = %d, want 3", got)
+	}
+}
+
+func TestLoginFailsFastOnInvalidGrant(t *testing.T) {
+	client, logins := newTestLoginClient(t, []func(w http.ResponseWriter){
+		func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error": "invalid_grant", "error_description": "Invalid user credentials"}`))
+		},
+	})
+
+	err := client.Get(context.Background(), "/apps/vmware.eda.nokia.com", nil, &map[string]any{})
+	var loginErr *LoginError
+	if !errors.As(err, &loginErr) || !loginErr.InvalidGrant() {
+		t.Fatalf("expected an invalid_grant LoginError, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "invalid_grant: Invalid user credentials") {
+		t.Errorf("Error() = %q", err.Error())
+	}
+	if got := logins.Load(); got != 1 {
+		t.Errorf("token requests = %d, want 1", got)
+	}
+}
@@ -0,0 +1,61 @@
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// Authentication modes
+	AUTH_MODE_PASSWORD           = "password"
+	AUTH_MODE_CLIENT_CREDENTIALS = "client_credentials"
+	AUTH_MODE_TOKEN              = "token"
+
+	KEY_CLIENT_CREDENTIALS_GRANT = "client_credentials"
+)
+
+// Returns the bearer token configured for the token authentication mode, either
+// the static access token, the contents of the token file or the output of the
+// token command, in that order of precedence
+func (c *EdaApiClient) readAccessToken(ctx context.Context) (string, error) {
+	switch {
+	case c.cfg.AccessToken != "":
+		return c.cfg.AccessToken, nil
+	case c.cfg.AccessTokenFile != "":
+		tflog.Debug(ctx, "readAccessToken()", map[string]any{"file": c.cfg.AccessTokenFile})
+		data, err := os.ReadFile(c.cfg.AccessTokenFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read access token file: %w", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	case c.cfg.AccessTokenCommand != "":
+		tflog.Debug(ctx, "readAccessToken()", map[string]any{"command": c.cfg.AccessTokenCommand})
+		return runTokenCommand(ctx, c.cfg.AccessTokenCommand)
+	default:
+		return "", fmt.Errorf("no access token configured for authentication mode: %s", AUTH_MODE_TOKEN)
+	}
+}
+
+// Runs the token command through the shell and returns its trimmed standard output
+func runTokenCommand(ctx context.Context, command string) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("access token command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,146 @@
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadAccessToken(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("  file-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		cfg      Config
+		expected string
+		err      string
+		unix     bool
+	}{
+		{
+			name:     "static token",
+			cfg:      Config{AccessToken: "static-token"},
+			expected: "static-token",
+		},
+		{
+			name:     "static token takes precedence",
+			cfg:      Config{AccessToken: "static-token", AccessTokenFile: tokenFile},
+			expected: "static-token",
+		},
+		{
+			name:     "token file",
+			cfg:      Config{AccessTokenFile: tokenFile},
+			expected: "file-token",
+		},
+		{
+			name: "missing token file",
+			cfg:  Config{AccessTokenFile: filepath.Join(t.TempDir(), "missing")},
+			err:  "failed to read access token file",
+		},
+		{
+			name:     "token command",
+			cfg:      Config{AccessTokenCommand: "echo ' command-token '"},
+			expected: "command-token",
+			unix:     true,
+		},
+		{
+			name: "failing token command",
+			cfg:  Config{AccessTokenCommand: "echo denied >&2; exit 3"},
+			err:  "access token command failed: exit status 3: denied",
+			unix: true,
+		},
+		{
+			name: "no token source",
+			cfg:  Config{},
+			err:  "no access token configured",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.unix && runtime.GOOS == "windows" {
+				t.Skip("token command test uses sh")
+			}
+			client := &EdaApiClient{cfg: &tt.cfg}
+			token, err := client.readAccessToken(context.Background())
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("readAccessToken() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if token != tt.expected {
+				t.Errorf("readAccessToken() = %q, want %q", token, tt.expected)
+			}
+		})
+	}
+}
+
+func TestClientCredentialsGrant(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf(OAUTH_URL, "eda"), func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+		}
+		expected := map[string]string{
+			KEY_GRANT_TYPE:    KEY_CLIENT_CREDENTIALS_GRANT,
+			KEY_CLIENT_ID:     "terraform",
+			KEY_CLIENT_SECRET: "service-secret",
+			KEY_USERNAME:      "",
+			KEY_PASSWORD:      "",
+		}
+		for k, v := range expected {
+			if got := r.PostForm.Get(k); got != v {
+				t.Errorf("token request %s = %q, want %q", k, got, v)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token": "service-token", "expires_in": 300}`))
+	})
+	mux.HandleFunc("/apps/vmware.eda.nokia.com", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer service-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name": "vmware.eda.nokia.com"}`))
+	})
+	// The service account does not need the Keycloak admin lookup of the client secret
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := NewEdaApiClient(context.Background(), &Config{
+		BaseURL:         server.URL,
+		AuthMode:        AUTH_MODE_CLIENT_CREDENTIALS,
+		EdaRealm:        "eda",
+		EdaClientID:     "terraform",
+		EdaClientSecret: "service-secret",
+		EdaUsername:     "admin",
+		EdaPassword:     "admin",
+		RestTimeout:     5 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := map[string]any{}
+	if err := client.Get(context.Background(), "/apps/vmware.eda.nokia.com", nil, &result); err != nil {
+		t.Fatal(err)
+	}
+	if result["name"] != "vmware.eda.nokia.com" {
+		t.Errorf("Get() = %v", result)
+	}
+}
@@ -0,0 +1,118 @@
+package apiclient
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/eda/rest"
+)
+
+// How long an endpoint that failed is only used when all the others failed too
+const ENDPOINT_COOL_DOWN = 30 * time.Second
+
+// Endpoints returns the base URLs of the EDA API, in order of preference: the base URL
+// followed by the other base URLs
+func (cfg *Config) Endpoints() []string {
+	endpoints := []string{}
+	for _, url := range append([]string{cfg.BaseURL}, cfg.BaseURLs...) {
+		url = strings.TrimSuffix(strings.TrimSpace(url), "/")
+		if url != "" && !slices.Contains(endpoints, url) {
+			endpoints = append(endpoints, url)
+		}
+	}
+	return endpoints
+}
+
+// endpoints tracks the health of the base URLs of the EDA API
+type endpoints struct {
+	mu   sync.Mutex
+	urls []string
+	// When each endpoint that failed can be used again
+	coolDownUntil map[string]time.Time
+}
+
+func newEndpoints(urls []string) *endpoints {
+	return &endpoints{urls: urls, coolDownUntil: map[string]time.Time{}}
+}
+
+// Returns the endpoints in the order they are tried: the healthy endpoints in order of
+// preference, then the endpoints cooling down, the first to recover first
+func (e *endpoints) candidates() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	now := time.Now()
+	healthy, coolingDown := []string{}, []string{}
+	for _, url := range e.urls {
+		if until, ok := e.coolDownUntil[url]; ok && now.Before(until) {
+			coolingDown = append(coolingDown, url)
+		} else {
+			healthy = append(healthy, url)
+		}
+	}
+	slices.SortStableFunc(coolingDown, func(a, b string) int {
+		return e.coolDownUntil[a].Compare(e.coolDownUntil[b])
+	})
+	return append(healthy, coolingDown...)
+}
+
+func (e *endpoints) markFailed(url string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.coolDownUntil[url] = time.Now().Add(ENDPOINT_COOL_DOWN)
+}
+
+func (e *endpoints) markHealthy(url string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.coolDownUntil, url)
+}
+
+// Runs a request against each endpoint in turn, until one of them is available. do is
+// given the context of the request and the base URL of the endpoint. Requests that are
+// not idempotent only fail over when they were not processed. Requests are not retried
+// against an unavailable endpoint while other endpoints remain, only against the last one.
+func (c *EdaApiClient) withEndpoint(ctx context.Context, idempotent bool,
+	do func(ctx context.Context, baseUrl string) (*resty.Response, error)) (*resty.Response, error) {
+	var resp *resty.Response
+	var err error
+	candidates := c.endpoints.candidates()
+	if len(candidates) == 0 {
+		return nil, errors.New("no EDA endpoints configured")
+	}
+	for i, baseUrl := range candidates {
+		reqCtx := ctx
+		if i < len(candidates)-1 {
+			reqCtx = rest.WithFailover(ctx)
+		}
+		resp, err = do(reqCtx, baseUrl)
+		status := 0
+		if err == nil {
+			status = resp.StatusCode()
+		}
+		if ctx.Err() != nil {
+			return resp, err
+		}
+		if !rest.IsEndpointFailure(status, err) {
+			c.endpoints.markHealthy(baseUrl)
+			return resp, err
+		}
+		c.endpoints.markFailed(baseUrl)
+		reason := rest.ClassifyRetry(status, err)
+		if i == len(candidates)-1 || (!idempotent && !reason.Safe) {
+			break
+		}
+		tflog.Warn(ctx, "withEndpoint()::Endpoint unavailable, failing over", map[string]any{
+			"endpoint": baseUrl,
+			"next":     candidates[i+1],
+			"reason":   reason.Message,
+			"coolDown": ENDPOINT_COOL_DOWN.String(),
+		})
+	}
+	return resp, err
+}
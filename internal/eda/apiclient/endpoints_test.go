@@ -0,0 +1,109 @@
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Returns a local fake EDA API issuing tokens and serving the app group,
+// replying 503 to all requests while down is set
+func newTestEndpoint(t *testing.T, down *atomic.Bool) (*httptest.Server, *atomic.Int32) {
+	var reqs atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf(OAUTH_URL, "eda"), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token": "test-token", "expires_in": 300}`))
+	})
+	mux.HandleFunc("/apps/vmware.eda.nokia.com", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name": "vmware.eda.nokia.com"}`))
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqs.Add(1)
+		if down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server, &reqs
+}
+
+func TestEndpointFailover(t *testing.T) {
+	var primaryDown, secondaryDown atomic.Bool
+	primary, primaryReqs := newTestEndpoint(t, &primaryDown)
+	secondary, secondaryReqs := newTestEndpoint(t, &secondaryDown)
+	primaryDown.Store(true)
+
+	client, err := NewEdaApiClient(context.Background(), &Config{
+		BaseURL:         primary.URL,
+		BaseURLs:        []string{secondary.URL},
+		EdaRealm:        "eda",
+		EdaClientID:     "eda",
+		EdaClientSecret: "secret",
+		EdaUsername:     "admin",
+		EdaPassword:     "admin",
+		RestTimeout:     5 * time.Second,
+		// The default retries, which must not hold up the failover
+		RestRetries:       3,
+		RestRetryInterval: 5 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+
+	// The login fails over to the secondary endpoint, and the request goes straight to it
+	get := func() {
+		t.Helper()
+		result := map[string]any{}
+		if err := client.Get(context.Background(), "/apps/vmware.eda.nokia.com", nil, &result); err != nil {
+			t.Fatal(err)
+		}
+		if result["name"] != "vmware.eda.nokia.com" {
+			t.Errorf("unexpected result: %v", result)
+		}
+	}
+	get()
+	if primaryReqs.Load() != 1 || secondaryReqs.Load() != 2 {
+		t.Errorf("requests = %d primary, %d secondary, want 1 and 2", primaryReqs.Load(), secondaryReqs.Load())
+	}
+
+	// The primary endpoint is avoided while it cools down, even once it has recovered
+	primaryDown.Store(false)
+	get()
+	if primaryReqs.Load() != 1 || secondaryReqs.Load() != 3 {
+		t.Errorf("requests = %d primary, %d secondary, want 1 and 3", primaryReqs.Load(), secondaryReqs.Load())
+	}
+
+	// The primary endpoint is used as a last resort while it cools down
+	secondaryDown.Store(true)
+	get()
+	if primaryReqs.Load() != 2 || secondaryReqs.Load() != 4 {
+		t.Errorf("requests = %d primary, %d secondary, want 2 and 4", primaryReqs.Load(), secondaryReqs.Load())
+	}
+
+	// Unavailable endpoints were not retried before failing over
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Errorf("failover took %s, want less than 1s", elapsed)
+	}
+}
+
+func TestWithEndpointWithoutEndpoints(t *testing.T) {
+	client := &EdaApiClient{endpoints: newEndpoints(nil)}
+	resp, err := client.withEndpoint(context.Background(), true, func(ctx context.Context, baseUrl string) (*resty.Response, error) {
+		t.Fatalf("request sent to %q without endpoints", baseUrl)
+		return nil, nil
+	})
+	if resp != nil || err == nil {
+		t.Errorf("withEndpoint() = %v, %v, want an error without endpoints", resp, err)
+	}
+}
@@ -0,0 +1,254 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/eda/utils"
+)
+
+// Keys of ErrorResponse values and ErrorItem errors that may name the field an error applies to
+var errorFieldKeys = []string{"field", "fieldPath", "path", "jsonPath"}
+
+// ErrorResponse is the generic error response returned by the EDA REST API
+type ErrorResponse struct {
+	Code                   int64           `json:"code,omitempty"`
+	Message                string          `json:"message"`
+	Type                   string          `json:"type"`
+	Domain                 string          `json:"domain,omitempty"`
+	Details                string          `json:"details,omitempty"`
+	Ref                    string          `json:"ref,omitempty"`
+	Internal               int64           `json:"internal,omitempty"`
+	Index                  *ErrorIndex     `json:"index,omitempty"`
+	Values                 map[string]any  `json:"values,omitempty"`
+	Dictionary             map[string]any  `json:"dictionary,omitempty"`
+	Errors                 []ErrorItem     `json:"errors,omitempty"`
+	CauseSimple            string          `json:"causeSimple,omitempty"`
+	CauseIsInternal        bool            `json:"causeIsInternal,omitempty"`
+	CauseWrapped           *ErrorResponse  `json:"causeWrapped,omitempty"`
+	CauseCollection        []ErrorResponse `json:"causeCollection,omitempty"`
+	CauseIndexedCollection []ErrorResponse `json:"causeIndexedCollection,omitempty"`
+}
+
+// ErrorIndex is the index of the item an indexed error applies to
+type ErrorIndex struct {
+	Index int64 `json:"index"`
+}
+
+// ErrorItem is a deprecated structured error, still returned by some EDA APIs
+type ErrorItem struct {
+	Error map[string]any `json:"error,omitempty"`
+	Type  string         `json:"type,omitempty"`
+}
+
+// FieldError is a single error decoded from an ErrorResponse. Field is the
+// API path of the request field the error applies to, like "spec.vcsaHost",
+// and is empty when the error is not specific to a field.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// APIError is returned for any non-2xx response from the EDA API.
+// Use errors.As to get the status code and the decoded error response.
+type APIError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Status     string
+	Body       string
+	// Response is nil if the body is not an EDA error response
+	Response *ErrorResponse
+}
+
+func newAPIError(method, pathUrl string, resp *resty.Response) *APIError {
+	apiErr := &APIError{
+		Method:     method,
+		Path:       pathUrl,
+		StatusCode: resp.StatusCode(),
+		Status:     resp.Status(),
+		Body:       resp.String(),
+	}
+	errResp := &ErrorResponse{}
+	if err := json.Unmarshal(resp.Body(), errResp); err == nil && (errResp.Message != "" || errResp.Type != "") {
+		apiErr.Response = errResp
+	}
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	if e.Response == nil {
+		return fmt.Sprintf("%s %s", e.Status, e.Body)
+	}
+	sb := strings.Builder{}
+	sb.WriteString(e.Status)
+	sb.WriteString(": ")
+	sb.WriteString(e.Response.message())
+	for _, fe := range e.FieldErrors() {
+		if fe.Message == e.Response.message() {
+			continue
+		}
+		sb.WriteString("\n  - ")
+		if fe.Field != "" {
+			sb.WriteString(fe.Field + ": ")
+		}
+		sb.WriteString(fe.Message)
+	}
+	return sb.String()
+}
+
+// Summary returns the top level message of the error
+func (e *APIError) Summary() string {
+	if e.Response == nil {
+		return e.Status
+	}
+	return e.Response.message()
+}
+
+// FieldErrors flattens the cause tree of the error response into the list of leaf errors.
+// Causes marked as internal are not descended into. A cause without a field of its own
+// inherits the field of its parent.
+func (e *APIError) FieldErrors() []FieldError {
+	if e.Response == nil {
+		return []FieldError{{Message: e.Body}}
+	}
+	return e.Response.fieldErrors("")
+}
+
+func (r *ErrorResponse) fieldErrors(parentField string) []FieldError {
+	field := fieldOf(r.Values)
+	if field == "" {
+		field = parentField
+	}
+	var causes []FieldError
+	if !r.CauseIsInternal {
+		if r.CauseWrapped != nil {
+			causes = append(causes, r.CauseWrapped.fieldErrors(field)...)
+		}
+		for i := range r.CauseCollection {
+			causes = append(causes, r.CauseCollection[i].fieldErrors(field)...)
+		}
+		for i := range r.CauseIndexedCollection {
+			causes = append(causes, r.CauseIndexedCollection[i].fieldErrors(field)...)
+		}
+		for _, item := range r.Errors {
+			itemField := fieldOf(item.Error)
+			if itemField == "" {
+				itemField = field
+			}
+			msg, _ := item.Error["message"].(string)
+			if msg == "" {
+				msg = fmt.Sprintf("%v", item.Error)
+			}
+			causes = append(causes, FieldError{Field: itemField, Message: msg})
+		}
+	}
+	if len(causes) > 0 {
+		return causes
+	}
+	msg := r.message()
+	if r.CauseSimple != "" && !r.CauseIsInternal {
+		msg += ": " + r.CauseSimple
+	} else if r.Details != "" {
+		msg += ": " + r.Details
+	}
+	return []FieldError{{Field: field, Message: msg}}
+}
+
+// Returns the message of the error, with any {{name}} escapes substituted from its values
+func (r *ErrorResponse) message() string {
+	msg := r.Message
+	if r.Index != nil {
+		msg = fmt.Sprintf("[%d] %s", r.Index.Index, msg)
+	}
+	for _, values := range []map[string]any{r.Values, r.Dictionary} {
+		for k, v := range values {
+			msg = strings.ReplaceAll(msg, "{{"+k+"}}", fmt.Sprintf("%v", v))
+		}
+	}
+	return msg
+}
+
+func fieldOf(values map[string]any) string {
+	for _, k := range errorFieldKeys {
+		if field, ok := values[k].(string); ok && field != "" {
+			return field
+		}
+	}
+	return ""
+}
+
+// OAuth error code of token requests rejecting the credentials or refresh token
+const OAUTH_INVALID_GRANT = "invalid_grant"
+
+// LoginError is returned when Keycloak rejects a token request. Code and Description
+// are the OAuth error and error_description of the response, if any.
+type LoginError struct {
+	AuthUrl     string
+	StatusCode  int
+	Status      string
+	Code        string
+	Description string
+	Body        string
+}
+
+func newLoginError(authUrl string, resp *resty.Response, keys *utils.SensitiveKeySet) *LoginError {
+	loginErr := &LoginError{
+		AuthUrl:    authUrl,
+		StatusCode: resp.StatusCode(),
+		Status:     resp.Status(),
+		Body:       keys.RedactString(resp.String()),
+	}
+	oauthErr := struct {
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}{}
+	if err := json.Unmarshal(resp.Body(), &oauthErr); err == nil {
+		loginErr.Code, loginErr.Description = oauthErr.Error, oauthErr.ErrorDescription
+	}
+	return loginErr
+}
+
+func (e *LoginError) Error() string {
+	msg := fmt.Sprintf("login to %s failed: %s", e.AuthUrl, e.Status)
+	switch {
+	case e.Code != "" && e.Description != "":
+		return msg + ": " + e.Code + ": " + e.Description
+	case e.Code != "":
+		return msg + ": " + e.Code
+	case e.Body != "":
+		return msg + ": " + e.Body
+	default:
+		return msg
+	}
+}
+
+// InvalidGrant returns whether the credentials or the refresh token were rejected
+func (e *LoginError) InvalidGrant() bool {
+	return e.Code == OAUTH_INVALID_GRANT
+}
+
+// StatusCode returns the HTTP status code of an APIError in the chain of err, or 0 if there is none
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+func IsConflict(err error) bool {
+	return StatusCode(err) == http.StatusConflict
+}
+
+func IsUnprocessable(err error) bool {
+	return StatusCode(err) == http.StatusUnprocessableEntity
+}
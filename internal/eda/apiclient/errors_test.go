@@ -0,0 +1,113 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+)
+
+func TestFieldErrors(t *testing.T) {
+	body := `{
+		"code": 422,
+		"message": "Validation of {{kind}} failed",
+		"values": {"kind": "VmwarePluginInstance"},
+		"causeCollection": [
+			{"message": "must be a valid host", "values": {"field": "spec.vcsaHost"}},
+			{"message": "invalid", "causeSimple": "unexpected error", "causeIsInternal": true}
+		],
+		"errors": [
+			{"error": {"path": "/spec/pollInterval", "message": "must be positive"}}
+		]
+	}`
+	errResp := &ErrorResponse{}
+	if err := json.Unmarshal([]byte(body), errResp); err != nil {
+		t.Fatal(err)
+	}
+	apiErr := &APIError{StatusCode: 422, Status: "422 Unprocessable Entity", Body: body, Response: errResp}
+
+	if apiErr.Summary() != "Validation of VmwarePluginInstance failed" {
+		t.Errorf("Summary() = %q", apiErr.Summary())
+	}
+	expected := []FieldError{
+		{Field: "spec.vcsaHost", Message: "must be a valid host"},
+		{Message: "invalid"},
+		{Field: "/spec/pollInterval", Message: "must be positive"},
+	}
+	if result := apiErr.FieldErrors(); !reflect.DeepEqual(result, expected) {
+		t.Errorf("FieldErrors() = %v, want %v", result, expected)
+	}
+
+	wrapped := fmt.Errorf("update failed: %w", apiErr)
+	if !IsUnprocessable(wrapped) || IsNotFound(wrapped) || IsConflict(wrapped) {
+		t.Errorf("unexpected status classification for %d", StatusCode(wrapped))
+	}
+}
+
+func TestNewAPIError(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		decoded  bool
+		summary  string
+		expected string
+	}{
+		{
+			name:     "error response",
+			status:   http.StatusNotFound,
+			body:     `{"code": 404, "message": "VmwarePluginInstance {{name}} not found", "values": {"name": "vmware1"}}`,
+			decoded:  true,
+			summary:  "VmwarePluginInstance vmware1 not found",
+			expected: "404 Not Found: VmwarePluginInstance vmware1 not found",
+		},
+		{
+			name:     "error response with causes",
+			status:   http.StatusUnprocessableEntity,
+			body:     `{"message": "invalid spec", "causeWrapped": {"message": "must be set", "values": {"field": "spec.vcsaHost"}}}`,
+			decoded:  true,
+			summary:  "invalid spec",
+			expected: "422 Unprocessable Entity: invalid spec\n  - spec.vcsaHost: must be set",
+		},
+		{
+			name:     "json body that is not an error response",
+			status:   http.StatusBadGateway,
+			body:     `{"status": "unavailable"}`,
+			summary:  "502 Bad Gateway",
+			expected: `502 Bad Gateway {"status": "unavailable"}`,
+		},
+		{
+			name:     "plain text body",
+			status:   http.StatusInternalServerError,
+			body:     "upstream error",
+			summary:  "500 Internal Server Error",
+			expected: "500 Internal Server Error upstream error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &resty.Response{RawResponse: &http.Response{
+				StatusCode: tt.status,
+				Status:     fmt.Sprintf("%d %s", tt.status, http.StatusText(tt.status)),
+			}}
+			resp.SetBody([]byte(tt.body))
+
+			apiErr := newAPIError(http.MethodGet, "/apps/vmware.eda.nokia.com", resp)
+			if apiErr.StatusCode != tt.status || apiErr.Body != tt.body {
+				t.Errorf("newAPIError() = %d %q, want %d %q", apiErr.StatusCode, apiErr.Body, tt.status, tt.body)
+			}
+			if decoded := apiErr.Response != nil; decoded != tt.decoded {
+				t.Errorf("newAPIError() decoded = %t, want %t", decoded, tt.decoded)
+			}
+			if apiErr.Summary() != tt.summary {
+				t.Errorf("Summary() = %q, want %q", apiErr.Summary(), tt.summary)
+			}
+			if apiErr.Error() != tt.expected {
+				t.Errorf("Error() = %q, want %q", apiErr.Error(), tt.expected)
+			}
+		})
+	}
+}
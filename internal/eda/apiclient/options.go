@@ -0,0 +1,46 @@
+package apiclient
+
+import (
+	"maps"
+	"strconv"
+)
+
+const (
+	// Detail levels kept in the transaction log for the transaction of a write request
+	DETAIL_LEVEL_STANDARD = "standard"
+	DETAIL_LEVEL_DETAILED = "detailed"
+
+	KEY_DETAIL_LEVEL     = "detailLevel"
+	KEY_DISABLE_BATCHING = "disableBatching"
+)
+
+// WriteOptions are the query parameters accepted by all the write operations
+// (POST, PUT, PATCH, DELETE), controlling the transaction resulting from the request
+type WriteOptions struct {
+	// One of DETAIL_LEVEL_STANDARD or DETAIL_LEVEL_DETAILED, the server default if empty
+	DetailLevel string
+	// Prevents the transaction from being bundled with others
+	DisableBatching bool
+}
+
+// DefaultWriteOptions returns the write options configured for the provider
+func (c *EdaApiClient) DefaultWriteOptions() WriteOptions {
+	return WriteOptions{
+		DetailLevel:     c.cfg.DetailLevel,
+		DisableBatching: c.cfg.DisableBatching,
+	}
+}
+
+// Returns a copy of queryParams with the write options added. Options left to
+// their default value are not sent.
+func (o WriteOptions) queryParams(queryParams map[string]string) map[string]string {
+	params := make(map[string]string, len(queryParams)+2)
+	maps.Copy(params, queryParams)
+	if o.DetailLevel != "" {
+		params[KEY_DETAIL_LEVEL] = o.DetailLevel
+	}
+	if o.DisableBatching {
+		params[KEY_DISABLE_BATCHING] = strconv.FormatBool(o.DisableBatching)
+	}
+	return params
+}
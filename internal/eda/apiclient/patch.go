@@ -0,0 +1,117 @@
+package apiclient
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/eda/utils"
+)
+
+const (
+	// JSON patch operations, as in RFC 6902
+	PATCH_OP_ADD     = "add"
+	PATCH_OP_REMOVE  = "remove"
+	PATCH_OP_REPLACE = "replace"
+
+	// Update methods
+	UPDATE_METHOD_PATCH = "patch"
+	UPDATE_METHOD_PUT   = "put"
+)
+
+// PatchOp is a single JSON patch operation (K8SPatchOp in the EDA API spec)
+type PatchOp struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	From  string `json:"from,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+// CreatePatch computes the RFC 6902 patch that transforms from into to.
+// Nested maps are compared key by key, while any other values (including lists)
+// are replaced as a whole. Operations on any of the ignorePaths, or below them,
+// are dropped from the result.
+func CreatePatch(from, to map[string]any, ignorePaths ...string) []PatchOp {
+	ops := diffMaps("", from, to)
+	if len(ignorePaths) == 0 {
+		return ops
+	}
+	filtered := make([]PatchOp, 0, len(ops))
+	for _, op := range ops {
+		if !isIgnoredPath(op.Path, ignorePaths) {
+			filtered = append(filtered, op)
+		}
+	}
+	return filtered
+}
+
+func diffMaps(prefix string, from, to map[string]any) []PatchOp {
+	ops := []PatchOp{}
+	for _, k := range sortedKeys(from) {
+		if _, ok := to[k]; !ok {
+			ops = append(ops, PatchOp{Op: PATCH_OP_REMOVE, Path: prefix + "/" + escapePointer(k)})
+		}
+	}
+	for _, k := range sortedKeys(to) {
+		path := prefix + "/" + escapePointer(k)
+		toVal := to[k]
+		fromVal, ok := from[k]
+		if !ok {
+			ops = append(ops, PatchOp{Op: PATCH_OP_ADD, Path: path, Value: toVal})
+			continue
+		}
+		fromMap, fromIsMap := fromVal.(map[string]any)
+		toMap, toIsMap := toVal.(map[string]any)
+		if fromIsMap && toIsMap {
+			ops = append(ops, diffMaps(path, fromMap, toMap)...)
+			continue
+		}
+		if !reflect.DeepEqual(fromVal, toVal) {
+			ops = append(ops, PatchOp{Op: PATCH_OP_REPLACE, Path: path, Value: toVal})
+		}
+	}
+	return ops
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Escapes a key for use as a JSON pointer reference token (RFC 6901)
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+func isIgnoredPath(path string, ignorePaths []string) bool {
+	for _, p := range ignorePaths {
+		if path == p || strings.HasPrefix(path, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactPatch returns a copy of patch for logging, with the values of operations
+// on the sensitive fields carried by ctx redacted
+func RedactPatch(ctx context.Context, patch []PatchOp) []PatchOp {
+	keys := utils.SensitiveKeys(ctx)
+	redacted := make([]PatchOp, len(patch))
+	for i, op := range patch {
+		redacted[i] = op
+		if op.Value == nil {
+			continue
+		}
+		if keys.IsSensitive(op.Path[strings.LastIndex(op.Path, "/")+1:]) {
+			redacted[i].Value = utils.REDACTED
+		} else {
+			redacted[i].Value = keys.Redact(op.Value)
+		}
+	}
+	return redacted
+}
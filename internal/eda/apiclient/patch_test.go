@@ -0,0 +1,66 @@
+package apiclient
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCreatePatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		from        map[string]any
+		to          map[string]any
+		ignorePaths []string
+		expected    []PatchOp
+	}{
+		{
+			name:     "no changes",
+			from:     map[string]any{"spec": map[string]any{"name": "a"}},
+			to:       map[string]any{"spec": map[string]any{"name": "a"}},
+			expected: []PatchOp{},
+		},
+		{
+			name: "replace nested value",
+			from: map[string]any{"spec": map[string]any{"name": "a", "vcsaHost": "h1"}},
+			to:   map[string]any{"spec": map[string]any{"name": "a", "vcsaHost": "h2"}},
+			expected: []PatchOp{
+				{Op: PATCH_OP_REPLACE, Path: "/spec/vcsaHost", Value: "h2"},
+			},
+		},
+		{
+			name: "add and remove values",
+			from: map[string]any{"spec": map[string]any{"pluginNamespace": "ns"}},
+			to:   map[string]any{"spec": map[string]any{"vcsaCertificate": "cert"}},
+			expected: []PatchOp{
+				{Op: PATCH_OP_REMOVE, Path: "/spec/pluginNamespace"},
+				{Op: PATCH_OP_ADD, Path: "/spec/vcsaCertificate", Value: "cert"},
+			},
+		},
+		{
+			name: "escaped map keys",
+			from: map[string]any{"metadata": map[string]any{"labels": map[string]any{"eda.nokia.com/owner": "a"}}},
+			to:   map[string]any{"metadata": map[string]any{"labels": map[string]any{"eda.nokia.com/owner": "b"}}},
+			expected: []PatchOp{
+				{Op: PATCH_OP_REPLACE, Path: "/metadata/labels/eda.nokia.com~1owner", Value: "b"},
+			},
+		},
+		{
+			name:        "ignored paths",
+			from:        map[string]any{"metadata": map[string]any{"labels": map[string]any{"a": "b"}, "name": "x"}},
+			to:          map[string]any{"metadata": map[string]any{"name": "y"}},
+			ignorePaths: []string{"/metadata/labels"},
+			expected: []PatchOp{
+				{Op: PATCH_OP_REPLACE, Path: "/metadata/name", Value: "y"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := CreatePatch(tt.from, tt.to, tt.ignorePaths...)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("CreatePatch() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
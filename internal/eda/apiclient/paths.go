@@ -0,0 +1,35 @@
+package apiclient
+
+import (
+	"maps"
+	"strings"
+)
+
+const (
+	KEY_NAMESPACE     = "namespace"
+	NAMESPACE_SEGMENT = "namespaces/{" + KEY_NAMESPACE + "}"
+
+	// Number of leading segments of an app API path before the resource
+	// segment, e.g. "/apps/{group}/{version}" for "/apps/{group}/{version}/{resource}"
+	appPathPrefixSegments = 4
+)
+
+// NamespacedPath returns the namespaced form of an app API path and its path parameters.
+// For example, with namespace "eda" the path "/apps/{group}/{version}/{resource}/{name}" becomes
+// "/apps/{group}/{version}/namespaces/{namespace}/{resource}/{name}", and the namespace path
+// parameter is set. The path and parameters are returned unchanged if namespace is empty,
+// if the path is already namespaced, or if the path does not address a resource.
+func NamespacedPath(pathUrl string, pathParams map[string]string, namespace string) (string, map[string]string) {
+	if namespace == "" || strings.Contains(pathUrl, "/"+NAMESPACE_SEGMENT+"/") {
+		return pathUrl, pathParams
+	}
+	segments := strings.SplitN(pathUrl, "/", appPathPrefixSegments+1)
+	if len(segments) <= appPathPrefixSegments {
+		return pathUrl, pathParams
+	}
+	newParams := make(map[string]string, len(pathParams)+1)
+	maps.Copy(newParams, pathParams)
+	newParams[KEY_NAMESPACE] = namespace
+	return strings.Join(segments[:appPathPrefixSegments], "/") + "/" + NAMESPACE_SEGMENT + "/" +
+		segments[appPathPrefixSegments], newParams
+}
@@ -0,0 +1,62 @@
+package apiclient
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNamespacedPath(t *testing.T) {
+	tests := []struct {
+		name           string
+		pathUrl        string
+		pathParams     map[string]string
+		namespace      string
+		expectedPath   string
+		expectedParams map[string]string
+	}{
+		{
+			name:           "no namespace",
+			pathUrl:        "/apps/vmware.eda.nokia.com/v1/vmwareplugininstances/{name}",
+			pathParams:     map[string]string{"name": "vc1"},
+			namespace:      "",
+			expectedPath:   "/apps/vmware.eda.nokia.com/v1/vmwareplugininstances/{name}",
+			expectedParams: map[string]string{"name": "vc1"},
+		},
+		{
+			name:           "resource path",
+			pathUrl:        "/apps/vmware.eda.nokia.com/v1/vmwareplugininstances/{name}",
+			pathParams:     map[string]string{"name": "vc1"},
+			namespace:      "eda",
+			expectedPath:   "/apps/vmware.eda.nokia.com/v1/namespaces/{namespace}/vmwareplugininstances/{name}",
+			expectedParams: map[string]string{"name": "vc1", "namespace": "eda"},
+		},
+		{
+			name:           "collection path",
+			pathUrl:        "/apps/vmware.eda.nokia.com/v1/vmwareplugininstances",
+			pathParams:     nil,
+			namespace:      "eda",
+			expectedPath:   "/apps/vmware.eda.nokia.com/v1/namespaces/{namespace}/vmwareplugininstances",
+			expectedParams: map[string]string{"namespace": "eda"},
+		},
+		{
+			name:           "version path",
+			pathUrl:        "/apps/vmware.eda.nokia.com/v1",
+			pathParams:     nil,
+			namespace:      "eda",
+			expectedPath:   "/apps/vmware.eda.nokia.com/v1",
+			expectedParams: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, params := NamespacedPath(tt.pathUrl, tt.pathParams, tt.namespace)
+			if path != tt.expectedPath {
+				t.Errorf("NamespacedPath() path = %q, want %q", path, tt.expectedPath)
+			}
+			if !reflect.DeepEqual(params, tt.expectedParams) {
+				t.Errorf("NamespacedPath() params = %v, want %v", params, tt.expectedParams)
+			}
+		})
+	}
+}
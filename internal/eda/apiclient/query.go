@@ -0,0 +1,50 @@
+package apiclient
+
+import (
+	"context"
+	"strings"
+)
+
+const (
+	QUERY_URL = "/core/query/v1/eql"
+
+	KEY_QUERY      = "query"
+	KEY_NAMESPACES = "namespaces"
+)
+
+// Query runs an EQL query and returns the rows of the result. Rows are returned as
+// decoded, the columns they hold depend on the table queried.
+func (c *EdaApiClient) Query(ctx context.Context, query string, namespaces ...string) ([]map[string]any, error) {
+	queryParams := map[string]string{KEY_QUERY: query}
+	if len(namespaces) > 0 {
+		queryParams[KEY_NAMESPACES] = strings.Join(namespaces, ",")
+	}
+	result := map[string]any{}
+	if err := c.GetByQuery(ctx, QUERY_URL, nil, queryParams, &result); err != nil {
+		return nil, err
+	}
+	return queryRows(result["data"]), nil
+}
+
+// Returns the rows of the data of a query result. Each row is either an object of
+// column values, or wraps that object in a "data" field.
+func queryRows(data any) []map[string]any {
+	items, _ := data.([]any)
+	rows := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		row, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		if inner, ok := row["data"].(map[string]any); ok {
+			row = inner
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// QuoteQueryString returns s as a double quoted EQL string literal
+func QuoteQueryString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,83 @@
+package apiclient
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// throttle limits the rate and the concurrency of the API requests of a client
+type throttle struct {
+	// Token bucket refilled at rate tokens per second up to burst, nil if the rate is not limited
+	bucket *tokenBucket
+	// Semaphore of the requests in flight, nil if the concurrency is not limited
+	slots chan struct{}
+}
+
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// Returns a throttle allowing requestsPerSecond requests per second, with bursts of up to
+// one second worth of requests, and maxConcurrent requests in flight. A limit of 0 is unlimited.
+func newThrottle(requestsPerSecond float64, maxConcurrent int) *throttle {
+	t := &throttle{}
+	if requestsPerSecond > 0 {
+		burst := math.Max(1, math.Ceil(requestsPerSecond))
+		t.bucket = &tokenBucket{rate: requestsPerSecond, burst: burst, tokens: burst, last: time.Now()}
+	}
+	if maxConcurrent > 0 {
+		t.slots = make(chan struct{}, maxConcurrent)
+	}
+	return t
+}
+
+// Waits for a free request slot, then for a token. Returns the function releasing the
+// slot once the request is done, or the context error if ctx is done while waiting.
+func (t *throttle) acquire(ctx context.Context) (func(), error) {
+	release := func() {}
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+			release = func() { <-t.slots }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if t.bucket != nil {
+		if err := t.bucket.take(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	return release, nil
+}
+
+// Takes a token, waiting for the bucket to refill if it is empty. Tokens are reserved
+// before waiting, so that concurrent callers are served in turn.
+func (b *tokenBucket) take(ctx context.Context) error {
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+	wait := time.Duration(-b.tokens / b.rate * float64(time.Second))
+	b.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+	if err := sleep(ctx, wait); err != nil {
+		// Give back the reserved token
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return err
+	}
+	return nil
+}
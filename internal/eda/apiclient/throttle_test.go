@@ -0,0 +1,71 @@
+package apiclient
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestThrottleLimitsConcurrency(t *testing.T) {
+	th := newThrottle(0, 2)
+	var inFlight, maxInFlight atomic.Int32
+	wg := sync.WaitGroup{}
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := th.acquire(context.Background())
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer release()
+			n := inFlight.Add(1)
+			for {
+				m := maxInFlight.Load()
+				if n <= m || maxInFlight.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			inFlight.Add(-1)
+		}()
+	}
+	wg.Wait()
+	if got := maxInFlight.Load(); got != 2 {
+		t.Errorf("max requests in flight = %d, want 2", got)
+	}
+}
+
+func TestThrottleLimitsRate(t *testing.T) {
+	// Bursts of 50 requests, then one request every 20ms
+	th := newThrottle(50, 0)
+	t0 := time.Now()
+	for range 55 {
+		release, err := th.acquire(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		release()
+	}
+	if elapsed := time.Since(t0); elapsed < 80*time.Millisecond {
+		t.Errorf("55 requests took %s, want at least 80ms", elapsed)
+	}
+}
+
+func TestThrottleCancelled(t *testing.T) {
+	th := newThrottle(1, 1)
+	release, err := th.acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := th.acquire(ctx); err != context.DeadlineExceeded {
+		t.Errorf("acquire() = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
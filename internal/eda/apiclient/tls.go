@@ -0,0 +1,92 @@
+package apiclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// NewTlsConfig builds the TLS configuration of the connection to the EDA API.
+// Configured CA certificates are trusted in addition to the system roots.
+func NewTlsConfig(cfg *Config) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: cfg.TlsSkipVerify,
+		ServerName:         cfg.TlsServerName,
+	}
+
+	if cfg.CaCert != "" || cfg.CaCertFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		caCerts, err := ReadCaCerts(cfg)
+		if err != nil {
+			return nil, err
+		}
+		for _, cert := range caCerts {
+			pool.AddCert(cert)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.ClientCert != "" || cfg.ClientKey != "" {
+		cert, err := tls.X509KeyPair([]byte(cfg.ClientCert), []byte(cfg.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate or key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// ReadCaCerts returns the CA certificates of the CA bundle and the CA bundle file
+func ReadCaCerts(cfg *Config) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	if cfg.CaCert != "" {
+		parsed, err := ParseCertificates([]byte(cfg.CaCert))
+		if err != nil {
+			return nil, fmt.Errorf("invalid CA certificate: %w", err)
+		}
+		certs = append(certs, parsed...)
+	}
+	if cfg.CaCertFile != "" {
+		data, err := os.ReadFile(cfg.CaCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate file: %w", err)
+		}
+		parsed, err := ParseCertificates(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CA certificate file %s: %w", cfg.CaCertFile, err)
+		}
+		certs = append(certs, parsed...)
+	}
+	return certs, nil
+}
+
+// ParseCertificates parses all the certificates of a PEM bundle. Blocks other than
+// certificates are an error, as is a bundle without any certificate.
+func ParseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected PEM block of type %s", block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+	return certs, nil
+}
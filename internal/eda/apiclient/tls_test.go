@@ -0,0 +1,182 @@
+package apiclient
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Returns a PEM encoded self-signed CA certificate with the given common name, and its PEM encoded key
+func newTestCertificate(t *testing.T, commonName string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		DNSNames:              []string{commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer}))
+}
+
+func TestParseCertificates(t *testing.T) {
+	cert1, key1 := newTestCertificate(t, "ca1.eda.local")
+	cert2, _ := newTestCertificate(t, "ca2.eda.local")
+	tests := []struct {
+		name     string
+		data     string
+		expected []string
+		err      string
+	}{
+		{
+			name:     "single certificate",
+			data:     cert1,
+			expected: []string{"ca1.eda.local"},
+		},
+		{
+			name:     "bundle",
+			data:     cert1 + "\n" + cert2,
+			expected: []string{"ca1.eda.local", "ca2.eda.local"},
+		},
+		{
+			name: "not PEM",
+			data: "-----BEGIN CERTIFICATE-----\nnot base64\n",
+			err:  "no PEM encoded certificate found",
+		},
+		{
+			name: "private key",
+			data: key1,
+			err:  "unexpected PEM block of type PRIVATE KEY",
+		},
+		{
+			name: "invalid certificate",
+			data: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("not DER")})),
+			err:  "x509",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			certs, err := ParseCertificates([]byte(tt.data))
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("ParseCertificates() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			names := []string{}
+			for _, cert := range certs {
+				names = append(names, cert.Subject.CommonName)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("ParseCertificates() = %v, want %v", names, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReadCaCerts(t *testing.T) {
+	cert1, _ := newTestCertificate(t, "ca1.eda.local")
+	cert2, _ := newTestCertificate(t, "ca2.eda.local")
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	if err := os.WriteFile(caFile, []byte(cert2), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	badFile := filepath.Join(dir, "bad.pem")
+	if err := os.WriteFile(badFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	certs, err := ReadCaCerts(&Config{CaCert: cert1, CaCertFile: caFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(certs) != 2 || certs[0].Subject.CommonName != "ca1.eda.local" || certs[1].Subject.CommonName != "ca2.eda.local" {
+		t.Errorf("ReadCaCerts() = %d certificates, want ca1 and ca2", len(certs))
+	}
+
+	for _, cfg := range []*Config{
+		{CaCert: "not a certificate"},
+		{CaCertFile: badFile},
+		{CaCertFile: filepath.Join(dir, "missing.pem")},
+	} {
+		if _, err := ReadCaCerts(cfg); err == nil {
+			t.Errorf("ReadCaCerts(%q, %q) succeeded, want an error", cfg.CaCert, cfg.CaCertFile)
+		}
+	}
+}
+
+func TestNewTlsConfig(t *testing.T) {
+	caCert, _ := newTestCertificate(t, "eda.local")
+	clientCert, clientKey := newTestCertificate(t, "terraform")
+	_, otherKey := newTestCertificate(t, "other")
+
+	tlsConfig, err := NewTlsConfig(&Config{
+		CaCert:        caCert,
+		ClientCert:    clientCert,
+		ClientKey:     clientKey,
+		TlsServerName: "eda.local",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tlsConfig.ServerName != "eda.local" || tlsConfig.InsecureSkipVerify {
+		t.Errorf("NewTlsConfig() server name = %q, skip verify = %t", tlsConfig.ServerName, tlsConfig.InsecureSkipVerify)
+	}
+	if len(tlsConfig.Certificates) != 1 {
+		t.Errorf("NewTlsConfig() client certificates = %d, want 1", len(tlsConfig.Certificates))
+	}
+	// The configured CA is trusted
+	certs, _ := ParseCertificates([]byte(caCert))
+	if _, err := certs[0].Verify(x509.VerifyOptions{Roots: tlsConfig.RootCAs, DNSName: "eda.local"}); err != nil {
+		t.Errorf("certificate signed by the configured CA not trusted: %v", err)
+	}
+
+	// System roots only, when no CA is configured
+	tlsConfig, err = NewTlsConfig(&Config{TlsSkipVerify: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tlsConfig.RootCAs != nil || !tlsConfig.InsecureSkipVerify {
+		t.Errorf("NewTlsConfig() without CA set root CAs or did not skip verify")
+	}
+
+	for _, cfg := range []*Config{
+		{CaCert: "not a certificate"},
+		{ClientCert: clientCert, ClientKey: otherKey},
+		{ClientCert: clientCert},
+	} {
+		if _, err := NewTlsConfig(cfg); err == nil {
+			t.Errorf("NewTlsConfig() succeeded, want an error")
+		}
+	}
+}
@@ -0,0 +1,383 @@
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/eda/rest"
+)
+
+const (
+	TRANSACTION_URL         = "/core/transaction/v2/transaction"
+	TRANSACTION_SUMMARY_URL = "/core/transaction/v2/result/summary/{transactionId}"
+
+	// Terminal states of a transaction
+	TRANSACTION_STATE_COMPLETE = "complete"
+	TRANSACTION_STATE_FAILED   = "failed"
+
+	// Interval between polls of the transaction summary, and how long to wait for
+	// a committed transaction to complete
+	TRANSACTION_POLL_INTERVAL = 1 * time.Second
+	TRANSACTION_TIMEOUT       = 5 * time.Minute
+)
+
+// GroupVersionKind identifies the type of a resource in a transaction
+type GroupVersionKind struct {
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+}
+
+// TransactionTarget identifies an existing resource in a transaction
+type TransactionTarget struct {
+	Gvk       GroupVersionKind `json:"gvk"`
+	Name      string           `json:"name"`
+	Namespace string           `json:"namespace,omitempty"`
+}
+
+// TransactionValue is the full resource of a create, replace or modify operation
+type TransactionValue struct {
+	Value map[string]any `json:"value"`
+}
+
+// TransactionPatch is a JSON patch operation on an existing resource
+type TransactionPatch struct {
+	PatchOps []PatchOp         `json:"patchOps"`
+	Target   TransactionTarget `json:"target"`
+}
+
+// TransactionType holds exactly one operation on a resource
+type TransactionType struct {
+	Create  *TransactionValue  `json:"create,omitempty"`
+	Replace *TransactionValue  `json:"replace,omitempty"`
+	Modify  *TransactionValue  `json:"modify,omitempty"`
+	Delete  *TransactionTarget `json:"delete,omitempty"`
+	Patch   *TransactionPatch  `json:"patch,omitempty"`
+}
+
+// TransactionCr is a single item of a transaction
+type TransactionCr struct {
+	Type TransactionType `json:"type"`
+}
+
+// Transaction is the body of a transaction request
+type Transaction struct {
+	Crs         []TransactionCr `json:"crs"`
+	Description string          `json:"description,omitempty"`
+	DryRun      bool            `json:"dryRun"`
+	Retain      bool            `json:"retain"`
+	ResultType  string          `json:"resultType,omitempty"`
+}
+
+// TransactionSummary is the subset of the transaction result summary used by the client
+type TransactionSummary struct {
+	Id            int64    `json:"id"`
+	State         string   `json:"state"`
+	Success       bool     `json:"success"`
+	DryRun        bool     `json:"dryRun"`
+	GeneralErrors []string `json:"generalErrors"`
+	IntentsRun    []struct {
+		IntentName TransactionTarget `json:"intentName"`
+		// Errors have a message, and may name the field they apply to like ErrorItem errors
+		Errors []map[string]any `json:"errors"`
+	} `json:"intentsRun"`
+}
+
+// TransactionItemResult is the outcome of a single item of a transaction
+type TransactionItemResult struct {
+	Operation string
+	Kind      string
+	Name      string
+	Namespace string
+	Errors    []FieldError
+}
+
+func (r TransactionItemResult) String() string {
+	name := r.Name
+	if r.Namespace != "" {
+		name = r.Namespace + "/" + name
+	}
+	s := fmt.Sprintf("%s %s %s: ", r.Operation, r.Kind, name)
+	if len(r.Errors) == 0 {
+		return s + "ok"
+	}
+	msgs := make([]string, len(r.Errors))
+	for i, fe := range r.Errors {
+		msgs[i] = fe.Message
+		if fe.Field != "" {
+			msgs[i] = fe.Field + ": " + fe.Message
+		}
+	}
+	return s + strings.Join(msgs, "; ")
+}
+
+// TransactionResult is the outcome of a transaction, as seen by one of its items
+type TransactionResult struct {
+	Id     int64
+	DryRun bool
+	// Index of the item submitted by the caller in Items
+	Index int
+	Items []TransactionItemResult
+}
+
+// Item returns the result of the item submitted by the caller
+func (r *TransactionResult) Item() TransactionItemResult {
+	return r.Items[r.Index]
+}
+
+// TransactionError is returned when a transaction completes without success.
+// All the items of the transaction failed together.
+type TransactionError struct {
+	Id            int64
+	DryRun        bool
+	GeneralErrors []string
+	Items         []TransactionItemResult
+}
+
+func (e *TransactionError) Error() string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("transaction %d failed", e.Id))
+	if e.DryRun {
+		sb.WriteString(" (dry run)")
+	}
+	for _, msg := range e.GeneralErrors {
+		sb.WriteString("\n  - " + msg)
+	}
+	for _, item := range e.Items {
+		sb.WriteString("\n  - " + item.String())
+	}
+	return sb.String()
+}
+
+// A batch of writes committed in a single transaction
+type transactionBatch struct {
+	// Context of the first write of the batch, used to commit the batch
+	ctx context.Context
+	// Writes of the batch, in submission order. Writes are removed from the batch when
+	// their writer gives up before the batch is committed.
+	writes []*batchWrite
+	// Set once the batch is closed to new writes and committed, guarded by the client txLock
+	committing bool
+	done       chan struct{}
+	result     *TransactionResult
+	err        error
+}
+
+// A write submitted to a transaction batch
+type batchWrite struct {
+	cr TransactionCr
+	// Index of the item of the write in the transaction, set when the batch is committed
+	index int
+}
+
+// Transact submits a write to the current batch of writes and waits for the batch to
+// be committed in a single transaction. All the writes submitted within the transaction
+// batch window of the first write of a batch succeed or fail together.
+//
+// If ctx is done before the batch is committed, the write is removed from the batch and
+// the context error is returned. Once the batch is committed the write can no longer be
+// withdrawn, so Transact waits for the outcome of the transaction whatever ctx.
+func (c *EdaApiClient) Transact(ctx context.Context, cr TransactionCr) (*TransactionResult, error) {
+	write := &batchWrite{cr: cr}
+	c.txLock.Lock()
+	batch := c.txBatch
+	if batch == nil {
+		batch = &transactionBatch{ctx: context.WithoutCancel(ctx), done: make(chan struct{})}
+		c.txBatch = batch
+		time.AfterFunc(c.cfg.TransactionBatchWindow, func() { c.commitBatch(batch) })
+	}
+	batch.writes = append(batch.writes, write)
+	writes := len(batch.writes)
+	c.txLock.Unlock()
+
+	tflog.Debug(ctx, "Transact()::Write added to the transaction batch", map[string]any{"writes": writes})
+
+	select {
+	case <-ctx.Done():
+		if c.withdraw(batch, write) {
+			tflog.Debug(ctx, "Transact()::Write removed from the transaction batch", map[string]any{"error": ctx.Err().Error()})
+			return nil, ctx.Err()
+		}
+		tflog.Warn(ctx, "Transact()::Context done while the transaction batch is committed, waiting for the transaction", nil)
+		<-batch.done
+	case <-batch.done:
+	}
+	if batch.err != nil {
+		return nil, batch.err
+	}
+	result := *batch.result
+	result.Index = write.index
+	return &result, nil
+}
+
+// Removes a write from its batch, unless the batch is already committed. Returns
+// whether the write was removed.
+func (c *EdaApiClient) withdraw(batch *transactionBatch, write *batchWrite) bool {
+	c.txLock.Lock()
+	defer c.txLock.Unlock()
+	if batch.committing {
+		return false
+	}
+	batch.writes = slices.DeleteFunc(batch.writes, func(w *batchWrite) bool { return w == write })
+	return true
+}
+
+// Closes the batch to new writes, commits it and releases the writers waiting for it.
+// A batch whose writes were all withdrawn is not committed.
+func (c *EdaApiClient) commitBatch(batch *transactionBatch) {
+	c.txLock.Lock()
+	if c.txBatch == batch {
+		c.txBatch = nil
+	}
+	batch.committing = true
+	items := make([]TransactionCr, len(batch.writes))
+	for i, write := range batch.writes {
+		write.index = i
+		items[i] = write.cr
+	}
+	c.txLock.Unlock()
+	defer close(batch.done)
+
+	if len(items) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(batch.ctx, TRANSACTION_TIMEOUT)
+	defer cancel()
+	batch.result, batch.err = c.RunTransaction(ctx, &Transaction{
+		Crs:         items,
+		Description: fmt.Sprintf("terraform-provider-vmware: %d change(s)", len(items)),
+		DryRun:      c.cfg.TransactionDryRun,
+		Retain:      true,
+	})
+}
+
+// RunTransaction commits a transaction and waits for it to complete
+func (c *EdaApiClient) RunTransaction(ctx context.Context, tx *Transaction) (*TransactionResult, error) {
+	tflog.Info(ctx, "RunTransaction()::API request", map[string]any{
+		"path":   TRANSACTION_URL,
+		"items":  len(tx.Crs),
+		"dryRun": tx.DryRun,
+	})
+
+	t0 := time.Now()
+	created := struct {
+		Id int64 `json:"id"`
+	}{}
+	if err := c.Execute(ctx, TRANSACTION_URL, rest.HTTP_POST, nil, nil, tx, &created); err != nil {
+		return nil, err
+	}
+
+	summary, err := c.waitForTransaction(ctx, created.Id)
+
+	tflog.Info(ctx, "RunTransaction()::API returned", map[string]any{
+		"transactionId": created.Id,
+		"timeTaken":     time.Since(t0).String(),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TransactionResult{
+		Id:     created.Id,
+		DryRun: tx.DryRun,
+		Items:  transactionItemResults(tx.Crs, summary),
+	}
+	if !summary.Success {
+		return nil, &TransactionError{
+			Id:            created.Id,
+			DryRun:        tx.DryRun,
+			GeneralErrors: summary.GeneralErrors,
+			Items:         result.Items,
+		}
+	}
+	return result, nil
+}
+
+// Polls the summary of a transaction until the transaction completes
+func (c *EdaApiClient) waitForTransaction(ctx context.Context, id int64) (*TransactionSummary, error) {
+	pathParams := map[string]string{"transactionId": strconv.FormatInt(id, 10)}
+	for {
+		summary := &TransactionSummary{}
+		err := c.Get(ctx, TRANSACTION_SUMMARY_URL, pathParams, summary)
+		// The summary is not available until the transaction has run
+		if err != nil && !IsNotFound(err) {
+			return nil, err
+		}
+		if err == nil && (summary.State == TRANSACTION_STATE_COMPLETE || summary.State == TRANSACTION_STATE_FAILED) {
+			return summary, nil
+		}
+		tflog.Debug(ctx, "waitForTransaction()::Transaction not complete", map[string]any{
+			"transactionId": id,
+			"state":         summary.State,
+		})
+		if err := sleep(ctx, TRANSACTION_POLL_INTERVAL); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Returns the result of each item of a transaction, with the errors of the intents run for it
+func transactionItemResults(crs []TransactionCr, summary *TransactionSummary) []TransactionItemResult {
+	results := make([]TransactionItemResult, len(crs))
+	for i, cr := range crs {
+		op, target := cr.Type.describe()
+		results[i] = TransactionItemResult{
+			Operation: op,
+			Kind:      target.Gvk.Kind,
+			Name:      target.Name,
+			Namespace: target.Namespace,
+		}
+		for _, intent := range summary.IntentsRun {
+			if intent.IntentName.Gvk.Kind != target.Gvk.Kind || intent.IntentName.Name != target.Name ||
+				intent.IntentName.Namespace != target.Namespace {
+				continue
+			}
+			for _, e := range intent.Errors {
+				msg, _ := e["message"].(string)
+				results[i].Errors = append(results[i].Errors, FieldError{Field: fieldOf(e), Message: msg})
+			}
+		}
+	}
+	return results
+}
+
+// Returns the name of the operation and the resource it applies to
+func (t TransactionType) describe() (string, TransactionTarget) {
+	switch {
+	case t.Create != nil:
+		return "create", t.Create.target()
+	case t.Replace != nil:
+		return "replace", t.Replace.target()
+	case t.Modify != nil:
+		return "modify", t.Modify.target()
+	case t.Delete != nil:
+		return "delete", *t.Delete
+	case t.Patch != nil:
+		return "patch", t.Patch.Target
+	default:
+		return "unknown", TransactionTarget{}
+	}
+}
+
+func (v *TransactionValue) target() TransactionTarget {
+	target := TransactionTarget{}
+	if apiVersion, ok := v.Value["apiVersion"].(string); ok {
+		group, version, found := strings.Cut(apiVersion, "/")
+		if !found {
+			group, version = "", apiVersion
+		}
+		target.Gvk.Group, target.Gvk.Version = group, version
+	}
+	target.Gvk.Kind, _ = v.Value["kind"].(string)
+	if metadata, ok := v.Value["metadata"].(map[string]any); ok {
+		target.Name, _ = metadata["name"].(string)
+		target.Namespace, _ = metadata["namespace"].(string)
+	}
+	return target
+}
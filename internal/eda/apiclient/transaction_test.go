@@ -0,0 +1,227 @@
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeTransactionServer is a local fake of the EDA transaction API. Transactions
+// fail when an item creates a resource named "invalid".
+type fakeTransactionServer struct {
+	mu           sync.Mutex
+	transactions []Transaction
+	// How long the creation of a transaction takes
+	commitDelay time.Duration
+}
+
+func (f *fakeTransactionServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer test-token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == TRANSACTION_URL:
+		time.Sleep(f.commitDelay)
+		tx := Transaction{}
+		if err := json.NewDecoder(r.Body).Decode(&tx); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.transactions = append(f.transactions, tx)
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": len(f.transactions)})
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/core/transaction/v2/result/summary/"):
+		id, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/core/transaction/v2/result/summary/"))
+		if err != nil || id < 1 || id > len(f.transactions) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		tx := f.transactions[id-1]
+		summary := map[string]any{"id": id, "state": TRANSACTION_STATE_COMPLETE, "success": true, "dryRun": tx.DryRun}
+		intents := []any{}
+		for _, cr := range tx.Crs {
+			if _, target := cr.Type.describe(); target.Name == "invalid" {
+				summary["success"] = false
+				intents = append(intents, map[string]any{
+					"intentName": target,
+					"errors":     []any{map[string]any{"message": "host is unreachable", "field": "spec.vcsaHost"}},
+				})
+			}
+		}
+		summary["intentsRun"] = intents
+		_ = json.NewEncoder(w).Encode(summary)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestTransactionClient(t *testing.T, fake *fakeTransactionServer) *EdaApiClient {
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	client, err := NewEdaApiClient(context.Background(), &Config{
+		BaseURL:                server.URL,
+		AuthMode:               AUTH_MODE_TOKEN,
+		AccessToken:            "test-token",
+		RestTimeout:            5 * time.Second,
+		TransactionMode:        true,
+		TransactionBatchWindow: 100 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func createCr(name string) TransactionCr {
+	return TransactionCr{Type: TransactionType{Create: &TransactionValue{Value: map[string]any{
+		"apiVersion": "vmware.eda.nokia.com/v1",
+		"kind":       "VmwarePluginInstance",
+		"metadata":   map[string]any{"name": name, "namespace": "eda"},
+	}}}}
+}
+
+func TestTransactBatchesWrites(t *testing.T) {
+	fake := &fakeTransactionServer{}
+	client := newTestTransactionClient(t, fake)
+
+	names := []string{"vc1", "vc2", "vc3"}
+	results := make([]*TransactionResult, len(names))
+	errs := make([]error, len(names))
+	wg := sync.WaitGroup{}
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = client.Transact(context.Background(), createCr(name))
+		}()
+	}
+	wg.Wait()
+
+	if len(fake.transactions) != 1 || len(fake.transactions[0].Crs) != len(names) {
+		t.Fatalf("expected a single transaction of %d items, got %v", len(names), fake.transactions)
+	}
+	for i, name := range names {
+		if errs[i] != nil {
+			t.Fatalf("Transact(%s) failed: %v", name, errs[i])
+		}
+		if results[i].Id != 1 {
+			t.Errorf("Transact(%s) transaction id = %d, want 1", name, results[i].Id)
+		}
+		if item := results[i].Item(); item.Name != name || item.Operation != "create" || len(item.Errors) != 0 {
+			t.Errorf("Transact(%s) item = %v", name, item)
+		}
+	}
+}
+
+func TestTransactFailsAllItems(t *testing.T) {
+	fake := &fakeTransactionServer{}
+	client := newTestTransactionClient(t, fake)
+	client.cfg.TransactionDryRun = true
+
+	errs := make([]error, 2)
+	wg := sync.WaitGroup{}
+	for i, name := range []string{"vc1", "invalid"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = client.Transact(context.Background(), createCr(name))
+		}()
+	}
+	wg.Wait()
+
+	if len(fake.transactions) != 1 || !fake.transactions[0].DryRun {
+		t.Fatalf("expected a single dry run transaction, got %v", fake.transactions)
+	}
+	for _, err := range errs {
+		txErr, ok := err.(*TransactionError)
+		if !ok {
+			t.Fatalf("expected a TransactionError, got %v", err)
+		}
+		if txErr.Id != 1 || !txErr.DryRun || len(txErr.Items) != 2 {
+			t.Errorf("unexpected transaction error: %v", txErr)
+		}
+		if !strings.Contains(txErr.Error(), "create VmwarePluginInstance eda/invalid: spec.vcsaHost: host is unreachable") {
+			t.Errorf("Error() = %q", txErr.Error())
+		}
+	}
+}
+
+func TestTransactWithdrawsCancelledWrite(t *testing.T) {
+	fake := &fakeTransactionServer{}
+	client := newTestTransactionClient(t, fake)
+
+	// The write of vc1 is cancelled within the batch window, before the batch is committed
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	var cancelledErr, err error
+	var result *TransactionResult
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, cancelledErr = client.Transact(ctx, createCr("vc1"))
+	}()
+	go func() {
+		defer wg.Done()
+		time.Sleep(5 * time.Millisecond)
+		result, err = client.Transact(context.Background(), createCr("vc2"))
+	}()
+	wg.Wait()
+
+	if cancelledErr != context.DeadlineExceeded {
+		t.Errorf("Transact(vc1) = %v, want %v", cancelledErr, context.DeadlineExceeded)
+	}
+	if err != nil {
+		t.Fatalf("Transact(vc2) failed: %v", err)
+	}
+	if len(fake.transactions) != 1 || len(fake.transactions[0].Crs) != 1 {
+		t.Fatalf("expected a single transaction of 1 item, got %v", fake.transactions)
+	}
+	if item := result.Item(); item.Name != "vc2" {
+		t.Errorf("Transact(vc2) item = %v", item)
+	}
+}
+
+func TestTransactWaitsForCommittedBatch(t *testing.T) {
+	fake := &fakeTransactionServer{commitDelay: 200 * time.Millisecond}
+	client := newTestTransactionClient(t, fake)
+
+	// The context is done while the batch is committed, after the batch window
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+	result, err := client.Transact(ctx, createCr("vc1"))
+	if err != nil {
+		t.Fatalf("Transact(vc1) failed: %v", err)
+	}
+	if result.Id != 1 || result.Item().Name != "vc1" {
+		t.Errorf("Transact(vc1) = %+v", result)
+	}
+}
+
+func TestTransactSkipsEmptyBatch(t *testing.T) {
+	fake := &fakeTransactionServer{}
+	client := newTestTransactionClient(t, fake)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := client.Transact(ctx, createCr("vc1")); err != context.DeadlineExceeded {
+		t.Errorf("Transact(vc1) = %v, want %v", err, context.DeadlineExceeded)
+	}
+	// Wait for the end of the batch window
+	time.Sleep(200 * time.Millisecond)
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if len(fake.transactions) != 0 {
+		t.Errorf("expected no transaction, got %v", fake.transactions)
+	}
+}
@@ -1,11 +1,14 @@
 package rest
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/eda/utils"
 )
 
 const (
@@ -16,6 +19,9 @@ const (
 	HTTP_DELETE  = "DELETE"
 	HTTP_HEAD    = "HEAD"
 	HTTP_OPTIONS = "OPTIONS"
+
+	CONTENT_TYPE_JSON       = "application/json"
+	CONTENT_TYPE_JSON_PATCH = "application/json-patch+json"
 )
 
 type ApiClient struct {
@@ -47,26 +53,57 @@ func (c *ApiClient) WithRetryInterval(retryInterval time.Duration) *ApiClient {
 	return c
 }
 
-func (c *ApiClient) WithDebug(debug bool) *ApiClient {
+// WithRetryPolicy retries the failed requests selected by the policy, waiting between
+// retries as the policy requires
+func (c *ApiClient) WithRetryPolicy(policy RetryPolicy) *ApiClient {
+	policy.apply(c.restClient)
+	return c
+}
+
+// WithDebug dumps the requests and responses, with the values of keys redacted
+func (c *ApiClient) WithDebug(debug bool, keys *utils.SensitiveKeySet) *ApiClient {
 	c.restClient.SetDebug(debug)
+	// Keep credentials and tokens out of the request and response dumps
+	c.restClient.OnRequestLog(func(rl *resty.RequestLog) error {
+		redactHeaders(rl.Header, keys)
+		rl.Body = keys.RedactString(rl.Body)
+		return nil
+	})
+	c.restClient.OnResponseLog(func(rl *resty.ResponseLog) error {
+		redactHeaders(rl.Header, keys)
+		rl.Body = keys.RedactString(rl.Body)
+		return nil
+	})
 	return c
 }
 
+func redactHeaders(header http.Header, keys *utils.SensitiveKeySet) {
+	for k := range header {
+		if keys.IsSensitive(k) {
+			header[k] = []string{utils.REDACTED}
+		}
+	}
+}
+
 func (c *ApiClient) WithTlsConfig(tlsConfig *tls.Config) *ApiClient {
 	c.restClient.SetTLSClientConfig(tlsConfig)
 	return c
 }
 
-func (c *ApiClient) DoLogin(authUrl string, oauthBody map[string]string, res any) (resp *resty.Response, err error) {
+// DoLogin posts an OAuth token request. Token requests have no side effects,
+// and are retried like idempotent requests.
+func (c *ApiClient) DoLogin(ctx context.Context, authUrl string, oauthBody map[string]string, res any) (resp *resty.Response, err error) {
 	request := c.restClient.R().
+		SetContext(withIdempotent(ctx)).
 		SetFormData(oauthBody).
 		SetResult(res)
 	return request.Post(authUrl)
 }
 
-func (c *ApiClient) DoPost(accessToken, pathUrl string,
+func (c *ApiClient) DoPost(ctx context.Context, accessToken, pathUrl string,
 	data any, result any, pathParams map[string]string) (*resty.Response, error) {
 	request := c.restClient.R().
+		SetContext(ctx).
 		SetAuthToken(accessToken).
 		SetPathParams(pathParams).
 		SetBody(data).
@@ -75,9 +112,10 @@ func (c *ApiClient) DoPost(accessToken, pathUrl string,
 	return doExecute(request, HTTP_POST, pathUrl)
 }
 
-func (c *ApiClient) DoGet(accessToken, pathUrl string,
+func (c *ApiClient) DoGet(ctx context.Context, accessToken, pathUrl string,
 	result any, pathParams map[string]string) (*resty.Response, error) {
 	request := c.restClient.R().
+		SetContext(ctx).
 		SetAuthToken(accessToken).
 		SetPathParams(pathParams).
 		SetResult(result).
@@ -85,9 +123,10 @@ func (c *ApiClient) DoGet(accessToken, pathUrl string,
 	return doExecute(request, HTTP_GET, pathUrl)
 }
 
-func (c *ApiClient) DoQuery(accessToken, pathUrl string,
+func (c *ApiClient) DoQuery(ctx context.Context, accessToken, pathUrl string,
 	result any, pathParams map[string]string, queryParams map[string]string) (*resty.Response, error) {
 	request := c.restClient.R().
+		SetContext(ctx).
 		SetAuthToken(accessToken).
 		SetPathParams(pathParams).
 		SetQueryParams(queryParams).
@@ -96,9 +135,10 @@ func (c *ApiClient) DoQuery(accessToken, pathUrl string,
 	return doExecute(request, HTTP_GET, pathUrl)
 }
 
-func (c *ApiClient) DoPut(accessToken, pathUrl string,
+func (c *ApiClient) DoPut(ctx context.Context, accessToken, pathUrl string,
 	data any, result any, pathParams map[string]string) (*resty.Response, error) {
 	request := c.restClient.R().
+		SetContext(ctx).
 		SetAuthToken(accessToken).
 		SetPathParams(pathParams).
 		SetBody(data).
@@ -107,9 +147,10 @@ func (c *ApiClient) DoPut(accessToken, pathUrl string,
 	return doExecute(request, HTTP_PUT, pathUrl)
 }
 
-func (c *ApiClient) DoDelete(accessToken, pathUrl string,
+func (c *ApiClient) DoDelete(ctx context.Context, accessToken, pathUrl string,
 	result any, pathParams map[string]string) (*resty.Response, error) {
 	request := c.restClient.R().
+		SetContext(ctx).
 		SetAuthToken(accessToken).
 		SetPathParams(pathParams).
 		SetResult(result).
@@ -117,7 +158,7 @@ func (c *ApiClient) DoDelete(accessToken, pathUrl string,
 	return doExecute(request, HTTP_DELETE, pathUrl)
 }
 
-func (c *ApiClient) DoExecute(
+func (c *ApiClient) DoExecute(ctx context.Context,
 	method, urlPath, accessToken string,
 	body any,
 	result any,
@@ -126,6 +167,7 @@ func (c *ApiClient) DoExecute(
 	headers map[string]string) (*resty.Response, error) {
 
 	request := c.restClient.R().
+		SetContext(ctx).
 		SetAuthToken(accessToken).
 		SetPathParams(pathParams).
 		SetQueryParams(queryParams).
@@ -134,8 +176,8 @@ func (c *ApiClient) DoExecute(
 		SetHeaders(headers)
 	if headers == nil {
 		request.SetHeaders(map[string]string{
-			"Content-Type": "application/json",
-			"Accept":       "application/json",
+			"Content-Type": CONTENT_TYPE_JSON,
+			"Accept":       CONTENT_TYPE_JSON,
 		})
 	}
 	return doExecute(request, method, urlPath)
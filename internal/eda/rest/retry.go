@@ -0,0 +1,204 @@
+package rest
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// Wait before the first retry, doubled on each retry up to the retry interval
+	RETRY_BASE_DELAY = 250 * time.Millisecond
+	// Upper bound of the wait requested by a Retry-After header
+	RETRY_AFTER_MAX = time.Minute
+)
+
+// RetryPolicy decides which failed requests are retried and how long to wait before each retry.
+//
+// Requests failing with 429 Too Many Requests or 503 Service Unavailable, or before reaching
+// the server, were not processed and are retried whatever their method. Requests failing with
+// 502 Bad Gateway, 504 Gateway Timeout or a transport error once sent may have been processed,
+// and are only retried when their method is idempotent.
+type RetryPolicy struct {
+	// Maximum number of retries of a request, 0 disables retries
+	MaxRetries int
+	// Upper bound of the exponential backoff between retries
+	MaxInterval time.Duration
+}
+
+// RetryReason is why a failed request can be retried
+type RetryReason struct {
+	// The failure is safe to retry whatever the request method
+	Safe bool
+	// Description of the failure
+	Message string
+}
+
+// Context key marking requests safe to replay whatever their method
+type idempotentKey struct{}
+
+// Returns a context marking the requests made with it as safe to replay whatever their method
+func withIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+// Context key marking requests that fail over to another endpoint
+type failoverKey struct{}
+
+// WithFailover returns a context marking the requests made with it as failing over to another
+// endpoint when theirs is unavailable, instead of being retried against it
+func WithFailover(ctx context.Context) context.Context {
+	return context.WithValue(ctx, failoverKey{}, true)
+}
+
+// IsEndpointFailure returns whether a request failing with status or err shows that the
+// endpoint is unavailable
+func IsEndpointFailure(status int, err error) bool {
+	if err != nil {
+		return ClassifyRetry(0, err) != nil
+	}
+	return status == http.StatusBadGateway || status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout
+}
+
+// IsIdempotent returns whether requests with method can be replayed without side effects
+func IsIdempotent(method string) bool {
+	switch method {
+	case HTTP_GET, HTTP_HEAD, HTTP_OPTIONS, HTTP_PUT, HTTP_DELETE:
+		return true
+	default:
+		return false
+	}
+}
+
+// ClassifyRetry returns why a request failing with status or err can be retried,
+// or nil if it must not be retried. Timeouts of the HTTP client are transport errors,
+// callers check whether the context of the request is done themselves.
+func ClassifyRetry(status int, err error) *RetryReason {
+	if err != nil {
+		var dnsErr *net.DNSError
+		var opErr *net.OpError
+		var certErr *tls.CertificateVerificationError
+		switch {
+		case errors.As(err, &certErr):
+			return nil
+		case errors.As(err, &dnsErr) || (errors.As(err, &opErr) && opErr.Op == "dial"):
+			// The request was not sent
+			return &RetryReason{Safe: true, Message: "connection failed: " + err.Error()}
+		default:
+			return &RetryReason{Message: "transport error: " + err.Error()}
+		}
+	}
+	switch status {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return &RetryReason{Safe: true, Message: fmt.Sprintf("status %d %s", status, http.StatusText(status))}
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return &RetryReason{Message: fmt.Sprintf("status %d %s", status, http.StatusText(status))}
+	default:
+		return nil
+	}
+}
+
+// ShouldRetry returns whether a request failing with status or err is retried, and why.
+// Requests that are not idempotent are only retried when they were not processed.
+func (p RetryPolicy) ShouldRetry(idempotent bool, status int, err error) (bool, string) {
+	reason := ClassifyRetry(status, err)
+	if reason == nil || (!reason.Safe && !idempotent) {
+		return false, ""
+	}
+	return true, reason.Message
+}
+
+// Backoff returns the jittered exponential wait before the given retry, counting from 1
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	if p.MaxInterval <= 0 {
+		return 0
+	}
+	delay := min(RETRY_BASE_DELAY, p.MaxInterval)
+	for i := 1; i < retry && delay < p.MaxInterval; i++ {
+		delay *= 2
+	}
+	delay = min(delay, p.MaxInterval)
+	// Wait between half and the full delay, so that concurrent clients spread their retries
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// Wait returns how long to wait before the given retry, counting from 1: the delay
+// requested by the Retry-After header of the response if any, otherwise the backoff
+func (p RetryPolicy) Wait(retry int, header http.Header) time.Duration {
+	if wait, ok := ParseRetryAfter(header, time.Now()); ok {
+		return min(wait, RETRY_AFTER_MAX)
+	}
+	return p.Backoff(retry)
+}
+
+// ParseRetryAfter returns the delay requested by the Retry-After header, given in
+// seconds or as an HTTP date, and whether the header holds a valid delay
+func ParseRetryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(secs)*time.Second, 0), secs >= 0
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// Registers the retry policy with the resty client
+func (p RetryPolicy) apply(client *resty.Client) {
+	client.SetRetryCount(p.MaxRetries)
+	// The wait is computed by the policy, resty only bounds it
+	client.SetRetryWaitTime(0)
+	client.SetRetryMaxWaitTime(max(RETRY_AFTER_MAX, p.MaxInterval))
+	client.AddRetryCondition(func(resp *resty.Response, err error) bool {
+		// Requests whose context is done are not retried, unlike those that hit the client timeout
+		if resp == nil || resp.Request.Context().Err() != nil {
+			return false
+		}
+		if resp.Request.Context().Value(failoverKey{}) != nil && IsEndpointFailure(resp.StatusCode(), err) {
+			return false
+		}
+		retry, _ := p.ShouldRetry(isIdempotentRequest(resp.Request), resp.StatusCode(), err)
+		return retry
+	})
+	client.AddRetryHook(func(resp *resty.Response, err error) {
+		// Hooks also run after the last attempt, which is not retried
+		if resp == nil || resp.Request.Attempt > p.MaxRetries {
+			return
+		}
+		_, reason := p.ShouldRetry(isIdempotentRequest(resp.Request), resp.StatusCode(), err)
+		tflog.Warn(resp.Request.Context(), "Retrying request", map[string]any{
+			"method":  resp.Request.Method,
+			"url":     resp.Request.URL,
+			"attempt": resp.Request.Attempt,
+			"reason":  reason,
+		})
+	})
+	client.SetRetryAfter(func(_ *resty.Client, resp *resty.Response) (time.Duration, error) {
+		wait := p.Wait(resp.Request.Attempt, resp.Header())
+		tflog.Debug(resp.Request.Context(), "Waiting before retry", map[string]any{
+			"method": resp.Request.Method,
+			"url":    resp.Request.URL,
+			"wait":   wait.String(),
+		})
+		// A zero wait makes resty fall back to its own backoff
+		return max(wait, time.Nanosecond), nil
+	})
+}
+
+// Returns whether a request can be replayed without side effects
+func isIdempotentRequest(r *resty.Request) bool {
+	return IsIdempotent(r.Method) || r.Context().Value(idempotentKey{}) != nil
+}
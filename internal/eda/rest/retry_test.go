@@ -0,0 +1,129 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		status   int
+		failures int32
+		wantErr  bool
+		wantReqs int32
+	}{
+		{name: "GET retried on 503", method: HTTP_GET, status: http.StatusServiceUnavailable, failures: 2, wantReqs: 3},
+		{name: "GET retried on 504", method: HTTP_GET, status: http.StatusGatewayTimeout, failures: 1, wantReqs: 2},
+		{name: "POST retried on 429", method: HTTP_POST, status: http.StatusTooManyRequests, failures: 1, wantReqs: 2},
+		{name: "POST not retried on 502", method: HTTP_POST, status: http.StatusBadGateway, failures: 1, wantErr: true, wantReqs: 1},
+		{name: "GET not retried on 500", method: HTTP_GET, status: http.StatusInternalServerError, failures: 1, wantErr: true, wantReqs: 1},
+		{name: "GET gives up after the max retries", method: HTTP_GET, status: http.StatusServiceUnavailable, failures: 5, wantErr: true, wantReqs: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reqs atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if reqs.Add(1) <= tt.failures {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(tt.status)
+					return
+				}
+				w.Header().Set("Content-Type", CONTENT_TYPE_JSON)
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			client := CreateApiClient().
+				WithBaseURL(server.URL).
+				WithRetryPolicy(RetryPolicy{MaxRetries: 3, MaxInterval: 10 * time.Millisecond})
+			result := map[string]any{}
+			resp, err := client.DoExecute(context.Background(), tt.method, "/", "token", nil, &result, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.IsError() != tt.wantErr {
+				t.Errorf("status = %s, want error %t", resp.Status(), tt.wantErr)
+			}
+			if got := reqs.Load(); got != tt.wantReqs {
+				t.Errorf("requests = %d, want %d", got, tt.wantReqs)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyTimeout(t *testing.T) {
+	var reqs atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The first request outlasts the client timeout
+		if reqs.Add(1) == 1 {
+			select {
+			case <-time.After(time.Second):
+			case <-r.Context().Done():
+			}
+		}
+		w.Header().Set("Content-Type", CONTENT_TYPE_JSON)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := CreateApiClient().
+		WithBaseURL(server.URL).
+		WithTimeout(100 * time.Millisecond).
+		WithRetryPolicy(RetryPolicy{MaxRetries: 3, MaxInterval: 10 * time.Millisecond})
+
+	// A request that hits the client timeout is retried
+	result := map[string]any{}
+	resp, err := client.DoExecute(context.Background(), HTTP_GET, "/", "token", nil, &result, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.IsError() {
+		t.Errorf("status = %s, want success", resp.Status())
+	}
+	if got := reqs.Load(); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+
+	// A request whose context expires is not
+	reqs.Store(0)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.DoExecute(ctx, HTTP_GET, "/", "token", nil, &result, nil, nil, nil); err == nil {
+		t.Error("error = nil, want the context deadline")
+	}
+	if got := reqs.Load(); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOk bool
+	}{
+		{value: "", wantOk: false},
+		{value: "3", want: 3 * time.Second, wantOk: true},
+		{value: "-1", wantOk: false},
+		{value: "Wed, 01 Jan 2025 12:00:30 GMT", want: 30 * time.Second, wantOk: true},
+		{value: "Wed, 01 Jan 2025 11:00:00 GMT", want: 0, wantOk: true},
+		{value: "soon", wantOk: false},
+	}
+	for _, tt := range tests {
+		header := http.Header{}
+		if tt.value != "" {
+			header.Set("Retry-After", tt.value)
+		}
+		got, ok := ParseRetryAfter(header, now)
+		if got != tt.want || ok != tt.wantOk {
+			t.Errorf("ParseRetryAfter(%q) = %s, %t, want %s, %t", tt.value, got, ok, tt.want, tt.wantOk)
+		}
+	}
+}
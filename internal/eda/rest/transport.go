@@ -0,0 +1,57 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// TransportConfig holds the settings of the HTTP transport of the client.
+// Settings left to their zero value keep the transport defaults.
+type TransportConfig struct {
+	// Proxy for all requests, instead of the HTTPS_PROXY and HTTP_PROXY environment variables
+	ProxyURL string
+	// Hosts, domains and CIDRs reached without the proxy, in the NO_PROXY format,
+	// instead of the NO_PROXY environment variable
+	NoProxy string
+	// Maximum number of idle connections kept open, in total and to each host
+	MaxIdleConns int
+	// How long idle connections are kept open
+	IdleConnTimeout time.Duration
+}
+
+// WithTransportConfig applies the transport settings to the underlying HTTP transport.
+// Fails when settings are given and the transport can not be configured.
+func (c *ApiClient) WithTransportConfig(cfg TransportConfig) (*ApiClient, error) {
+	if cfg == (TransportConfig{}) {
+		return c, nil
+	}
+	transport, err := c.restClient.Transport()
+	if err != nil {
+		return c, fmt.Errorf("cannot apply the proxy and connection pool settings: %w", err)
+	}
+	if cfg.ProxyURL != "" || cfg.NoProxy != "" {
+		proxyCfg := httpproxy.FromEnvironment()
+		if cfg.ProxyURL != "" {
+			proxyCfg.HTTPProxy, proxyCfg.HTTPSProxy = cfg.ProxyURL, cfg.ProxyURL
+		}
+		if cfg.NoProxy != "" {
+			proxyCfg.NoProxy = cfg.NoProxy
+		}
+		proxyFunc := proxyCfg.ProxyFunc()
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxyFunc(req.URL)
+		}
+	}
+	if cfg.MaxIdleConns > 0 {
+		transport.MaxIdleConns = cfg.MaxIdleConns
+		transport.MaxIdleConnsPerHost = cfg.MaxIdleConns
+	}
+	if cfg.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = cfg.IdleConnTimeout
+	}
+	return c, nil
+}
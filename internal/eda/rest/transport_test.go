@@ -0,0 +1,66 @@
+package rest
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWithTransportConfig(t *testing.T) {
+	client, err := CreateApiClient().WithTransportConfig(TransportConfig{
+		ProxyURL:        "http://proxy.example.com:3128",
+		NoProxy:         "direct.example.com,.internal",
+		MaxIdleConns:    20,
+		IdleConnTimeout: 10 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport, err := client.restClient.Transport()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transport.MaxIdleConns != 20 || transport.MaxIdleConnsPerHost != 20 || transport.IdleConnTimeout != 10*time.Second {
+		t.Errorf("connection pool settings not applied: %d, %d, %s",
+			transport.MaxIdleConns, transport.MaxIdleConnsPerHost, transport.IdleConnTimeout)
+	}
+
+	tests := []struct {
+		url       string
+		wantProxy string
+	}{
+		{url: "https://eda.example.com/apps", wantProxy: "http://proxy.example.com:3128"},
+		{url: "http://eda.example.com/apps", wantProxy: "http://proxy.example.com:3128"},
+		{url: "https://direct.example.com/apps", wantProxy: ""},
+		{url: "https://eda.internal/apps", wantProxy: ""},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		proxy, err := transport.Proxy(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := ""
+		if proxy != nil {
+			got = proxy.String()
+		}
+		if got != tt.wantProxy {
+			t.Errorf("proxy for %s = %q, want %q", tt.url, got, tt.wantProxy)
+		}
+	}
+}
+
+func TestWithTransportConfigError(t *testing.T) {
+	client := CreateApiClient()
+	// The transport settings can only be applied to an *http.Transport
+	client.restClient.SetTransport(http.NewFileTransport(http.Dir(".")))
+	if _, err := client.WithTransportConfig(TransportConfig{ProxyURL: "http://proxy.example.com:3128"}); err == nil {
+		t.Error("error = nil, want an error when the transport can not be configured")
+	}
+	if _, err := client.WithTransportConfig(TransportConfig{}); err != nil {
+		t.Errorf("error = %v, want none without transport settings", err)
+	}
+}
@@ -0,0 +1,201 @@
+package utils
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const REDACTED = "<redacted>"
+
+var (
+	// Keys whose values are never logged, matched regardless of case and of
+	// snake_case or camelCase spelling
+	builtInSensitiveKeys = []string{
+		"access_token",
+		"authorization",
+		"client_key",
+		"client_secret",
+		"id_token",
+		"password",
+		"private_key",
+		"refresh_token",
+		"secret",
+		"token",
+		"vcsa_certificate",
+	}
+	// The built-in sensitive keys, used when no other keys are configured
+	defaultSensitiveKeys = NewSensitiveKeySet()
+
+	// Matches "key": "value" pairs in JSON text and key=value pairs in form encoded text
+	jsonPairRegex = regexp.MustCompile(`"([A-Za-z0-9_\-]+)"(\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	formPairRegex = regexp.MustCompile(`(^|[&\s])([A-Za-z0-9_\-]+)=([^&\s]*)`)
+)
+
+// SensitiveKeySet is a set of keys whose values are redacted in logs. It holds the
+// built-in sensitive keys and the extra keys it was created with, and is read-only,
+// so that each API client masks its own keys.
+type SensitiveKeySet struct {
+	// Normalized key => spellings of the key
+	keys map[string][]string
+}
+
+// NewSensitiveKeySet returns the set of the built-in sensitive keys and of extraKeys
+func NewSensitiveKeySet(extraKeys ...string) *SensitiveKeySet {
+	s := &SensitiveKeySet{keys: map[string][]string{}}
+	for _, key := range slices.Concat(builtInSensitiveKeys, extraKeys) {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		norm := normalizeKey(key)
+		for _, spelling := range []string{key, snakeToLowerCamel(key), camelToLowerSnake(key)} {
+			if !slices.Contains(s.keys[norm], spelling) {
+				s.keys[norm] = append(s.keys[norm], spelling)
+			}
+		}
+	}
+	return s
+}
+
+// Context key of the sensitive keys of the logs of a request
+type sensitiveKeysKey struct{}
+
+// WithSensitiveKeys returns a context carrying the sensitive keys redacted in its logs
+func WithSensitiveKeys(ctx context.Context, keys *SensitiveKeySet) context.Context {
+	return context.WithValue(ctx, sensitiveKeysKey{}, keys)
+}
+
+// SensitiveKeys returns the sensitive keys carried by ctx, or the built-in sensitive keys
+func SensitiveKeys(ctx context.Context) *SensitiveKeySet {
+	if keys, ok := ctx.Value(sensitiveKeysKey{}).(*SensitiveKeySet); ok && keys != nil {
+		return keys
+	}
+	return defaultSensitiveKeys
+}
+
+// Keys returns the spellings of all sensitive keys, for use as log field keys
+func (s *SensitiveKeySet) Keys() []string {
+	keys := []string{}
+	for _, spellings := range s.keys {
+		keys = append(keys, spellings...)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// IsSensitive returns true if the values of key must not be logged
+func (s *SensitiveKeySet) IsSensitive(key string) bool {
+	_, ok := s.keys[normalizeKey(key)]
+	return ok
+}
+
+// Redact returns a deep copy of v, with the values of sensitive keys in any nested
+// maps replaced by REDACTED. Values other than maps and slices are returned as is.
+func (s *SensitiveKeySet) Redact(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		redacted := make(map[string]any, len(val))
+		for k, item := range val {
+			if s.IsSensitive(k) && item != nil {
+				redacted[k] = REDACTED
+			} else {
+				redacted[k] = s.Redact(item)
+			}
+		}
+		return redacted
+	case map[string]string:
+		redacted := make(map[string]string, len(val))
+		for k, item := range val {
+			if s.IsSensitive(k) && item != "" {
+				redacted[k] = REDACTED
+			} else {
+				redacted[k] = item
+			}
+		}
+		return redacted
+	case []map[string]any:
+		redacted := make([]map[string]any, len(val))
+		for i, item := range val {
+			redacted[i] = s.Redact(item).(map[string]any)
+		}
+		return redacted
+	case []any:
+		redacted := make([]any, len(val))
+		for i, item := range val {
+			redacted[i] = s.Redact(item)
+		}
+		return redacted
+	default:
+		return v
+	}
+}
+
+// RedactString redacts the values of sensitive keys in JSON or form encoded text
+func (s *SensitiveKeySet) RedactString(str string) string {
+	str = jsonPairRegex.ReplaceAllStringFunc(str, func(pair string) string {
+		m := jsonPairRegex.FindStringSubmatch(pair)
+		if !s.IsSensitive(m[1]) {
+			return pair
+		}
+		return `"` + m[1] + `"` + m[2] + `"` + REDACTED + `"`
+	})
+	return formPairRegex.ReplaceAllStringFunc(str, func(pair string) string {
+		m := formPairRegex.FindStringSubmatch(pair)
+		if !s.IsSensitive(m[2]) {
+			return pair
+		}
+		return m[1] + m[2] + "=" + REDACTED
+	})
+}
+
+// IsSensitiveKey returns true if the values of key are redacted by the built-in sensitive keys
+func IsSensitiveKey(key string) bool {
+	return defaultSensitiveKeys.IsSensitive(key)
+}
+
+// Redact redacts the values of the built-in sensitive keys in v, see SensitiveKeySet.Redact
+func Redact(v any) any {
+	return defaultSensitiveKeys.Redact(v)
+}
+
+// RedactString redacts the values of the built-in sensitive keys in s, see SensitiveKeySet.RedactString
+func RedactString(s string) string {
+	return defaultSensitiveKeys.RedactString(s)
+}
+
+func normalizeKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r == '-' {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, key)
+}
+
+func snakeToLowerCamel(key string) string {
+	parts := strings.Split(key, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+func camelToLowerSnake(key string) string {
+	sb := strings.Builder{}
+	for i, r := range key {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				sb.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	input := map[string]any{
+		"spec": map[string]any{
+			"vcsaHost":        "https://vcsa",
+			"vcsaCertificate": "-----BEGIN CERTIFICATE-----",
+		},
+		"items": []any{map[string]any{"client_secret": "s3cr3t", "name": "a"}},
+	}
+	expected := map[string]any{
+		"spec": map[string]any{
+			"vcsaHost":        "https://vcsa",
+			"vcsaCertificate": REDACTED,
+		},
+		"items": []any{map[string]any{"client_secret": REDACTED, "name": "a"}},
+	}
+	if result := Redact(input); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Redact() = %v, want %v", result, expected)
+	}
+	if input["spec"].(map[string]any)["vcsaCertificate"] == REDACTED {
+		t.Errorf("Redact() modified its input")
+	}
+}
+
+func TestRedactString(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "json",
+			input:    `{"access_token":"abc","token_type":"Bearer","refreshToken": "d\"ef"}`,
+			expected: `{"access_token":"<redacted>","token_type":"Bearer","refreshToken": "<redacted>"}`,
+		},
+		{
+			name:     "form",
+			input:    "grant_type=password&username=admin&password=admin",
+			expected: "grant_type=password&username=admin&password=<redacted>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := RedactString(tt.input); result != tt.expected {
+				t.Errorf("RedactString() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSensitiveKeySet(t *testing.T) {
+	first := NewSensitiveKeySet("vcsa_user")
+	second := NewSensitiveKeySet("api_key")
+
+	tests := []struct {
+		keys      *SensitiveKeySet
+		key       string
+		sensitive bool
+	}{
+		{keys: first, key: "vcsaUser", sensitive: true},
+		{keys: first, key: "apiKey", sensitive: false},
+		{keys: first, key: "password", sensitive: true},
+		{keys: second, key: "api_key", sensitive: true},
+		{keys: second, key: "vcsa_user", sensitive: false},
+		{keys: SensitiveKeys(context.Background()), key: "vcsaUser", sensitive: false},
+		{keys: SensitiveKeys(WithSensitiveKeys(context.Background(), first)), key: "vcsaUser", sensitive: true},
+	}
+	for _, tt := range tests {
+		if result := tt.keys.IsSensitive(tt.key); result != tt.sensitive {
+			t.Errorf("IsSensitive(%q) = %t, want %t", tt.key, result, tt.sensitive)
+		}
+	}
+}
@@ -61,6 +61,15 @@ func GetEnvIntWithDefault(key string, defaultVal int) int {
 	return defaultVal
 }
 
+func GetEnvFloatWithDefault(key string, defaultVal float64) float64 {
+	if val := os.Getenv(key); val != "" {
+		if ret, err := strconv.ParseFloat(val, 64); err == nil {
+			return ret
+		}
+	}
+	return defaultVal
+}
+
 func GetEnvDurationWithDefault(key string, defaultVal time.Duration) time.Duration {
 	if val := os.Getenv(key); val != "" {
 		if dur, err := time.ParseDuration(val); err == nil {
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/davecgh/go-spew/spew"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/datasource_app_group"
@@ -37,6 +36,7 @@ func (d *appGroupDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 }
 
 func (d *appGroupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = tfutils.MaskSensitiveFields(ctx, d.client.SensitiveKeys())
 	var data datasource_app_group.AppGroupModel
 
 	// Read Terraform prior state data into the model
@@ -56,7 +56,7 @@ func (d *appGroupDataSource) Read(ctx context.Context, req datasource.ReadReques
 	// Read API call logic
 	tflog.Info(ctx, "Read()::API request", map[string]any{
 		"path":  read_ds_appGroup,
-		"data":  spew.Sdump(data),
+		"data":  tfutils.RedactedDump(ctx, &data),
 		"query": queryParams,
 	})
 
@@ -66,12 +66,12 @@ func (d *appGroupDataSource) Read(ctx context.Context, req datasource.ReadReques
 
 	tflog.Info(ctx, "Read()::API returned", map[string]any{
 		"path":      read_ds_appGroup,
-		"result":    spew.Sdump(result),
+		"result":    tfutils.RedactedDump(ctx, result),
 		"timeTaken": time.Since(t0).String(),
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading resource", err.Error())
+		addApiErrorDiagnostics(&resp.Diagnostics, "Error reading resource", err)
 		return
 	}
 
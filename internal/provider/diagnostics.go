@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/eda/apiclient"
+	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/tfutils"
+)
+
+// Adds the diagnostics for an error returned by the API client.
+// Cancellation and expired deadlines are reported as such. If err is an APIError,
+// an error is added for each cause reported by the server, scoped to the attribute
+// it refers to when the server names a request field. Failed transactions are reported
+// with the result of each of their items.
+func addApiErrorDiagnostics(diags *diag.Diagnostics, summary string, err error) {
+	if isCancelled(err) {
+		addCancelledDiagnostic(diags, summary, err)
+		return
+	}
+	var txErr *apiclient.TransactionError
+	if errors.As(err, &txErr) {
+		diags.AddError(fmt.Sprintf("%s: transaction %d failed", summary, txErr.Id), txErr.Error())
+		return
+	}
+	var dryRunErr *dryRunError
+	if errors.As(err, &dryRunErr) {
+		diags.AddError(fmt.Sprintf("%s: transaction %d was a dry run", summary, dryRunErr.result.Id), dryRunErr.Error())
+		return
+	}
+	var apiErr *apiclient.APIError
+	if !errors.As(err, &apiErr) || apiErr.Response == nil {
+		diags.AddError(summary, err.Error())
+		return
+	}
+	summary += ": " + apiErr.Summary()
+	// Details of the error reported by the transaction engine, more verbose with the 'detailed' detail level
+	var details string
+	if apiErr.Response.Details != "" {
+		details = "\n\nDetails: " + apiErr.Response.Details
+	}
+	for _, fe := range apiErr.FieldErrors() {
+		if p, ok := attributePath(fe.Field); ok {
+			diags.AddAttributeError(p, summary, fe.Message+details)
+			continue
+		}
+		detail := fe.Message
+		if fe.Field != "" {
+			detail = fe.Field + ": " + detail
+		}
+		diags.AddError(summary, apiErr.Status+": "+detail+details)
+	}
+}
+
+// Adds the diagnostics for an error of the dry run validating a planned resource. The errors
+// of the validated resource are scoped to the attribute they refer to, when the server names
+// a request field.
+func addValidationDiagnostics(diags *diag.Diagnostics, summary string, err error) {
+	var txErr *apiclient.TransactionError
+	if !errors.As(err, &txErr) {
+		addApiErrorDiagnostics(diags, summary, err)
+		return
+	}
+	summary = fmt.Sprintf("%s: dry run transaction %d failed", summary, txErr.Id)
+	for _, msg := range txErr.GeneralErrors {
+		diags.AddError(summary, msg)
+	}
+	for _, item := range txErr.Items {
+		for _, fe := range item.Errors {
+			if p, ok := attributePath(fe.Field); ok {
+				diags.AddAttributeError(p, summary, fe.Message)
+				continue
+			}
+			detail := fe.Message
+			if fe.Field != "" {
+				detail = fe.Field + ": " + detail
+			}
+			diags.AddError(summary, detail)
+		}
+	}
+	// A failed transaction always reports at least one diagnostic
+	if !diags.HasError() {
+		diags.AddError(summary, txErr.Error())
+	}
+}
+
+// dryRunError stops a write whose dry run transaction succeeded, as the
+// resource was not changed and must not be saved in the state
+type dryRunError struct {
+	result *apiclient.TransactionResult
+}
+
+func (e *dryRunError) Error() string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("The dry run of transaction %d succeeded, no changes were committed. "+
+		"Unset transaction_dry_run to apply the changes.", e.result.Id))
+	for _, item := range e.result.Items {
+		sb.WriteString("\n  - " + item.String())
+	}
+	return sb.String()
+}
+
+func isCancelled(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+func addCancelledDiagnostic(diags *diag.Diagnostics, summary string, err error) {
+	diags.AddError("Operation cancelled",
+		summary+": the operation was cancelled or its deadline expired before it completed.\n\n"+err.Error())
+}
+
+// Attributes of type map, whose keys are kept verbatim in attribute paths
+var mapAttributes = []string{"labels", "annotations"}
+
+// Converts the API path of a request field, either in dotted form ("spec.vcsaHost",
+// "spec.items[0].name") or as a JSON pointer ("/spec/vcsaHost"), to the path of the
+// matching Terraform attribute. Keys of map attributes are kept as is: in dotted form,
+// the rest of the field after a map attribute is the key ("metadata.labels.app.kubernetes.io/name").
+func attributePath(field string) (path.Path, bool) {
+	field = strings.TrimPrefix(strings.TrimPrefix(field, "$"), ".")
+	sep := "."
+	if strings.HasPrefix(field, "/") {
+		field = strings.TrimPrefix(field, "/")
+		sep = "/"
+	}
+	if field == "" {
+		return path.Empty(), false
+	}
+	field = strings.ReplaceAll(strings.ReplaceAll(field, "[", sep), "]", "")
+
+	var p path.Path
+	segments := strings.Split(field, sep)
+	for i := 0; i < len(segments); i++ {
+		segment := segments[i]
+		if segment == "" {
+			return path.Empty(), false
+		}
+		segment = strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+		if i > 0 && slices.Contains(mapAttributes, segments[i-1]) {
+			if sep == "." {
+				segment = strings.Join(segments[i:], sep)
+				i = len(segments)
+			}
+			p = p.AtMapKey(segment)
+			continue
+		}
+		if idx, err := strconv.ParseInt(segment, 10, 64); err == nil && i > 0 {
+			p = p.AtListIndex(int(idx))
+			continue
+		}
+		name := tfutils.CamelToSnake(segment)
+		if i == 0 {
+			p = path.Root(name)
+		} else {
+			p = p.AtName(name)
+		}
+	}
+	return p, true
+}
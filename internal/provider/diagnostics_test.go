@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestAttributePath(t *testing.T) {
+	tests := []struct {
+		name     string
+		field    string
+		expected path.Path
+		ok       bool
+	}{
+		{
+			name:     "dotted field",
+			field:    "spec.vcsaHost",
+			expected: path.Root("spec").AtName("vcsa_host"),
+			ok:       true,
+		},
+		{
+			name:     "json path",
+			field:    "$.spec.vcsaHost",
+			expected: path.Root("spec").AtName("vcsa_host"),
+			ok:       true,
+		},
+		{
+			name:     "json pointer",
+			field:    "/spec/pollInterval",
+			expected: path.Root("spec").AtName("poll_interval"),
+			ok:       true,
+		},
+		{
+			name:     "list index",
+			field:    "spec.items[1].name",
+			expected: path.Root("spec").AtName("items").AtListIndex(1).AtName("name"),
+			ok:       true,
+		},
+		{
+			name:     "dotted label key",
+			field:    "metadata.labels.app.kubernetes.io/name",
+			expected: path.Root("metadata").AtName("labels").AtMapKey("app.kubernetes.io/name"),
+			ok:       true,
+		},
+		{
+			name:     "json pointer annotation key",
+			field:    "/metadata/annotations/example.com~1fooBar",
+			expected: path.Root("metadata").AtName("annotations").AtMapKey("example.com/fooBar"),
+			ok:       true,
+		},
+		{
+			name:  "empty field",
+			field: "",
+			ok:    false,
+		},
+		{
+			name:  "empty segment",
+			field: "spec..vcsaHost",
+			ok:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := attributePath(tt.field)
+			if ok != tt.ok {
+				t.Fatalf("attributePath(%q) ok = %t, want %t", tt.field, ok, tt.ok)
+			}
+			if ok && !result.Equal(tt.expected) {
+				t.Errorf("attributePath(%q) = %s, want %s", tt.field, result, tt.expected)
+			}
+		})
+	}
+}
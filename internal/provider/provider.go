@@ -2,14 +2,24 @@ package provider
 
 import (
 	"context"
+	"crypto/tls"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/eda/apiclient"
@@ -19,35 +29,82 @@ import (
 
 const (
 	// Environment variables
-	ENV_EDA_BASE_URL        = "BASE_URL"
-	ENV_KC_REALM            = "KEYCLOAK_MASTER_REALM"
-	ENV_KC_CLIENT_ID        = "KEYCLOAK_ADMIN_CLIENT_ID"
-	ENV_KC_USERNAME         = "KEYCLOAK_ADMIN_USERNAME"
-	ENV_KC_PASSWORD         = "KEYCLOAK_ADMIN_PASSWORD"
-	ENV_EDA_CLIENT_ID       = "CLIENT_ID"
-	ENV_EDA_CLIENT_SECRET   = "CLIENT_SECRET"
-	ENV_EDA_REALM           = "REALM"
-	ENV_EDA_USERNAME        = "USERNAME"
-	ENV_EDA_PASSWORD        = "PASSWORD"
-	ENV_TLS_SKIP_VERIFY     = "TLS_SKIP_VERIFY"
-	ENV_REST_DEBUG          = "REST_DEBUG"
-	ENV_REST_TIMEOUT        = "REST_TIMEOUT"
-	ENV_REST_RETRIES        = "REST_RETRIES"
-	ENV_REST_RETRY_INTERVAL = "REST_RETRY_INTERVAL"
+	ENV_EDA_BASE_URL             = "BASE_URL"
+	ENV_EDA_BASE_URLS            = "BASE_URLS"
+	ENV_KC_REALM                 = "KEYCLOAK_MASTER_REALM"
+	ENV_KC_CLIENT_ID             = "KEYCLOAK_ADMIN_CLIENT_ID"
+	ENV_KC_USERNAME              = "KEYCLOAK_ADMIN_USERNAME"
+	ENV_KC_PASSWORD              = "KEYCLOAK_ADMIN_PASSWORD"
+	ENV_EDA_CLIENT_ID            = "CLIENT_ID"
+	ENV_EDA_CLIENT_SECRET        = "CLIENT_SECRET"
+	ENV_EDA_REALM                = "REALM"
+	ENV_EDA_USERNAME             = "USERNAME"
+	ENV_EDA_PASSWORD             = "PASSWORD"
+	ENV_TLS_SKIP_VERIFY          = "TLS_SKIP_VERIFY"
+	ENV_REST_DEBUG               = "REST_DEBUG"
+	ENV_REST_TIMEOUT             = "REST_TIMEOUT"
+	ENV_REST_RETRIES             = "REST_RETRIES"
+	ENV_REST_RETRY_INTERVAL      = "REST_RETRY_INTERVAL"
+	ENV_UPDATE_METHOD            = "UPDATE_METHOD"
+	ENV_WAIT_FOR_READY           = "WAIT_FOR_READY"
+	ENV_READY_POLL_INTERVAL      = "READY_POLL_INTERVAL"
+	ENV_READY_STATUS_FIELD       = "READY_STATUS_FIELD"
+	ENV_READY_STATUS_VALUES      = "READY_STATUS_VALUES"
+	ENV_FAILED_STATUS_VALUES     = "FAILED_STATUS_VALUES"
+	ENV_TOKEN_REFRESH_MARGIN     = "TOKEN_REFRESH_MARGIN"
+	ENV_AUTH_MODE                = "AUTH_MODE"
+	ENV_ACCESS_TOKEN             = "ACCESS_TOKEN"
+	ENV_ACCESS_TOKEN_FILE        = "ACCESS_TOKEN_FILE"
+	ENV_ACCESS_TOKEN_COMMAND     = "ACCESS_TOKEN_COMMAND"
+	ENV_LOG_MASKED_KEYS          = "LOG_MASKED_KEYS"
+	ENV_CA_CERT                  = "CA_CERT"
+	ENV_CA_CERT_FILE             = "CA_CERT_FILE"
+	ENV_CLIENT_CERT              = "CLIENT_CERT"
+	ENV_CLIENT_KEY               = "CLIENT_KEY"
+	ENV_TLS_SERVER_NAME          = "TLS_SERVER_NAME"
+	ENV_DETAIL_LEVEL             = "DETAIL_LEVEL"
+	ENV_DISABLE_BATCHING         = "DISABLE_BATCHING"
+	ENV_TRANSACTION_MODE         = "TRANSACTION_MODE"
+	ENV_TRANSACTION_DRY_RUN      = "TRANSACTION_DRY_RUN"
+	ENV_TRANSACTION_BATCH_WINDOW = "TRANSACTION_BATCH_WINDOW"
+	ENV_PLAN_VALIDATION          = "PLAN_VALIDATION"
+	ENV_ALARM_WARNINGS           = "ALARM_WARNINGS"
+	ENV_ALARM_ERROR_SEVERITY     = "ALARM_ERROR_SEVERITY"
+	ENV_DEVIATION_ERRORS         = "DEVIATION_ERRORS"
+	ENV_MAX_REQUESTS_PER_SECOND  = "MAX_REQUESTS_PER_SECOND"
+	ENV_MAX_CONCURRENT_REQUESTS  = "MAX_CONCURRENT_REQUESTS"
+	ENV_PROXY_URL                = "PROXY_URL"
+	ENV_NO_PROXY                 = "NO_PROXY"
+	ENV_MAX_IDLE_CONNS           = "MAX_IDLE_CONNS"
+	ENV_IDLE_CONN_TIMEOUT        = "IDLE_CONN_TIMEOUT"
 
 	// Default values
-	DEF_KC_REALM            = "master"
-	DEF_KC_CLIENT_ID        = "admin-cli"
-	DEF_EDA_REALM           = "eda"
-	DEF_EDA_CLIENT_ID       = "eda"
-	DEF_USERNAME            = "admin"
-	DEF_PASSWORD            = "admin"
-	DEF_REST_TIMEOUT        = 15 * time.Second
-	DEF_REST_RETRIES        = 3
-	DEF_REST_RETRY_INTERVAL = 5 * time.Second
+	DEF_KC_REALM                 = "master"
+	DEF_KC_CLIENT_ID             = "admin-cli"
+	DEF_EDA_REALM                = "eda"
+	DEF_EDA_CLIENT_ID            = "eda"
+	DEF_USERNAME                 = "admin"
+	DEF_PASSWORD                 = "admin"
+	DEF_REST_TIMEOUT             = 15 * time.Second
+	DEF_REST_RETRIES             = 3
+	DEF_REST_RETRY_INTERVAL      = 5 * time.Second
+	DEF_UPDATE_METHOD            = apiclient.UPDATE_METHOD_PATCH
+	DEF_READY_POLL_INTERVAL      = 5 * time.Second
+	DEF_READY_TIMEOUT            = 10 * time.Minute
+	DEF_TOKEN_REFRESH_MARGIN     = 30 * time.Second
+	DEF_AUTH_MODE                = apiclient.AUTH_MODE_PASSWORD
+	DEF_TRANSACTION_BATCH_WINDOW = 2 * time.Second
 )
 
-var _ provider.Provider = (*vmwareProvider)(nil)
+// Provider attributes holding durations like "15s", which are parsed before
+// the provider config is converted to the API client config
+var durationAttributes = []string{"rest_timeout", "rest_retry_interval", "ready_poll_interval", "token_refresh_margin",
+	"transaction_batch_window", "idle_conn_timeout"}
+
+var (
+	_ provider.Provider                       = (*vmwareProvider)(nil)
+	_ provider.ProviderWithEphemeralResources = (*vmwareProvider)(nil)
+)
 
 func New(ver string) func() provider.Provider {
 	return func() provider.Provider {
@@ -60,21 +117,54 @@ type vmwareProvider struct {
 }
 
 type providerModel struct {
-	BaseURL           types.String `tfsdk:"base_url"`
-	KcRealm           types.String `tfsdk:"keycloak_master_realm"`
-	KcClientID        types.String `tfsdk:"keycloak_admin_client_id"`
-	KcUsername        types.String `tfsdk:"keycloak_admin_username"`
-	KcPassword        types.String `tfsdk:"keycloak_admin_password"`
-	EdaRealm          types.String `tfsdk:"realm"`
-	EdaClientID       types.String `tfsdk:"client_id"`
-	EdaClientSecret   types.String `tfsdk:"client_secret"`
-	EdaUsername       types.String `tfsdk:"username"`
-	EdaPassword       types.String `tfsdk:"password"`
-	TlsSkipVerify     types.Bool   `tfsdk:"tls_skip_verify"`
-	RestDebug         types.Bool   `tfsdk:"rest_debug"`
-	RestTimeout       types.String `tfsdk:"rest_timeout"`
-	RestRetries       types.Int64  `tfsdk:"rest_retries"`
-	RestRetryInterval types.String `tfsdk:"rest_retry_interval"`
+	BaseURL                types.String  `tfsdk:"base_url"`
+	BaseURLs               types.List    `tfsdk:"base_urls"`
+	KcRealm                types.String  `tfsdk:"keycloak_master_realm"`
+	KcClientID             types.String  `tfsdk:"keycloak_admin_client_id"`
+	KcUsername             types.String  `tfsdk:"keycloak_admin_username"`
+	KcPassword             types.String  `tfsdk:"keycloak_admin_password"`
+	EdaRealm               types.String  `tfsdk:"realm"`
+	EdaClientID            types.String  `tfsdk:"client_id"`
+	EdaClientSecret        types.String  `tfsdk:"client_secret"`
+	EdaUsername            types.String  `tfsdk:"username"`
+	EdaPassword            types.String  `tfsdk:"password"`
+	TlsSkipVerify          types.Bool    `tfsdk:"tls_skip_verify"`
+	RestDebug              types.Bool    `tfsdk:"rest_debug"`
+	RestTimeout            types.String  `tfsdk:"rest_timeout"`
+	RestRetries            types.Int64   `tfsdk:"rest_retries"`
+	RestRetryInterval      types.String  `tfsdk:"rest_retry_interval"`
+	UpdateMethod           types.String  `tfsdk:"update_method"`
+	WaitForReady           types.Bool    `tfsdk:"wait_for_ready"`
+	ReadyPollInterval      types.String  `tfsdk:"ready_poll_interval"`
+	ReadyStatusField       types.String  `tfsdk:"ready_status_field"`
+	ReadyStatusValues      types.List    `tfsdk:"ready_status_values"`
+	FailedStatusValues     types.List    `tfsdk:"failed_status_values"`
+	TokenRefreshMargin     types.String  `tfsdk:"token_refresh_margin"`
+	AuthMode               types.String  `tfsdk:"auth_mode"`
+	AccessToken            types.String  `tfsdk:"access_token"`
+	AccessTokenFile        types.String  `tfsdk:"access_token_file"`
+	AccessTokenCommand     types.String  `tfsdk:"access_token_command"`
+	LogMaskedKeys          types.List    `tfsdk:"log_masked_keys"`
+	CaCert                 types.String  `tfsdk:"ca_cert"`
+	CaCertFile             types.String  `tfsdk:"ca_cert_file"`
+	ClientCert             types.String  `tfsdk:"client_cert"`
+	ClientKey              types.String  `tfsdk:"client_key"`
+	TlsServerName          types.String  `tfsdk:"tls_server_name"`
+	DetailLevel            types.String  `tfsdk:"detail_level"`
+	DisableBatching        types.Bool    `tfsdk:"disable_batching"`
+	TransactionMode        types.Bool    `tfsdk:"transaction_mode"`
+	TransactionDryRun      types.Bool    `tfsdk:"transaction_dry_run"`
+	TransactionBatchWindow types.String  `tfsdk:"transaction_batch_window"`
+	PlanValidation         types.Bool    `tfsdk:"plan_validation"`
+	AlarmWarnings          types.Bool    `tfsdk:"alarm_warnings"`
+	AlarmErrorSeverity     types.String  `tfsdk:"alarm_error_severity"`
+	DeviationErrors        types.Bool    `tfsdk:"deviation_errors"`
+	MaxRequestsPerSecond   types.Float64 `tfsdk:"max_requests_per_second"`
+	MaxConcurrentRequests  types.Int64   `tfsdk:"max_concurrent_requests"`
+	ProxyURL               types.String  `tfsdk:"proxy_url"`
+	NoProxy                types.String  `tfsdk:"no_proxy"`
+	MaxIdleConns           types.Int64   `tfsdk:"max_idle_conns"`
+	IdleConnTimeout        types.String  `tfsdk:"idle_conn_timeout"`
 }
 
 func (p *vmwareProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
@@ -84,6 +174,12 @@ func (p *vmwareProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Description: "Base URL",
 				Optional:    true,
 			},
+			"base_urls": schema.ListAttribute{
+				Description: "Other base URLs of the same EDA deployment. Requests fail over to them in order when the base URL " +
+					"is unavailable, and an endpoint that failed is avoided for 30s",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
 			"keycloak_master_realm": schema.StringAttribute{
 				Description: "Keycloak Realm",
 				Optional:    true,
@@ -112,6 +208,7 @@ func (p *vmwareProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 			"client_secret": schema.StringAttribute{
 				Description: "EDA Client Secret",
 				Optional:    true,
+				Sensitive:   true,
 			},
 			"username": schema.StringAttribute{
 				Description: "EDA Username",
@@ -135,11 +232,171 @@ func (p *vmwareProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Optional:    true,
 			},
 			"rest_retries": schema.Int64Attribute{
-				Description: "REST Retries",
+				Description: "REST Retries: maximum number of retries of requests and logins failing with a transient error",
 				Optional:    true,
 			},
 			"rest_retry_interval": schema.StringAttribute{
-				Description: "REST Retry Interval",
+				Description: "REST Retry Interval: upper bound of the jittered exponential backoff between retries, unless the server requests a longer wait with Retry-After",
+				Optional:    true,
+			},
+			"update_method": schema.StringAttribute{
+				Description: "Method used to update resources: 'patch' sends a JSON patch of the changed fields, 'put' replaces the whole resource",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(apiclient.UPDATE_METHOD_PATCH, apiclient.UPDATE_METHOD_PUT),
+				},
+			},
+			"wait_for_ready": schema.BoolAttribute{
+				Description: "Wait for resources to report a ready state after create and update, within the resource's create/update timeouts. " +
+					"The state is read from ready_status_field",
+				Optional: true,
+			},
+			"ready_poll_interval": schema.StringAttribute{
+				Description: "Interval between status polls while waiting for a resource to become ready",
+				Optional:    true,
+			},
+			"ready_status_field": schema.StringAttribute{
+				Description: "Status field reporting the state of resources while waiting for them to become ready, " +
+					"with dots between nested field names, like 'operationalState'. Required with wait_for_ready",
+				Optional: true,
+			},
+			"ready_status_values": schema.ListAttribute{
+				Description: "Values of ready_status_field meaning the resource is ready, compared case-insensitively. Required with wait_for_ready",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"failed_status_values": schema.ListAttribute{
+				Description: "Values of ready_status_field meaning the resource has failed, which stop the wait with an error",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"token_refresh_margin": schema.StringAttribute{
+				Description: "Refresh access tokens this long before they expire",
+				Optional:    true,
+			},
+			"auth_mode": schema.StringAttribute{
+				Description: "Authentication mode: 'password' uses the password grant, 'client_credentials' the client credentials grant " +
+					"of the client_id/client_secret service account, 'token' a pre-issued bearer token",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(apiclient.AUTH_MODE_PASSWORD, apiclient.AUTH_MODE_CLIENT_CREDENTIALS, apiclient.AUTH_MODE_TOKEN),
+				},
+			},
+			"access_token": schema.StringAttribute{
+				Description: "Bearer token used with the 'token' auth mode",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"access_token_file": schema.StringAttribute{
+				Description: "File holding the bearer token used with the 'token' auth mode",
+				Optional:    true,
+			},
+			"access_token_command": schema.StringAttribute{
+				Description: "Shell command printing the bearer token used with the 'token' auth mode",
+				Optional:    true,
+			},
+			"log_masked_keys": schema.ListAttribute{
+				Description: "Additional keys whose values are masked in logs, besides passwords, secrets, tokens and certificates",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"ca_cert": schema.StringAttribute{
+				Description: "PEM encoded CA certificates trusted to verify the EDA API server, in addition to the system roots",
+				Optional:    true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Description: "File holding PEM encoded CA certificates trusted to verify the EDA API server, in addition to the system roots",
+				Optional:    true,
+			},
+			"client_cert": schema.StringAttribute{
+				Description: "PEM encoded client certificate for mutual TLS",
+				Optional:    true,
+			},
+			"client_key": schema.StringAttribute{
+				Description: "PEM encoded private key of the client certificate",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"tls_server_name": schema.StringAttribute{
+				Description: "Server name used to verify the certificate of the EDA API server, if different from the host of the base URL",
+				Optional:    true,
+			},
+			"detail_level": schema.StringAttribute{
+				Description: "Default detail level kept in the transaction log for the transactions of write requests, 'standard' or 'detailed'. With 'detailed', the transaction of each write is reported as a warning",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(apiclient.DETAIL_LEVEL_STANDARD, apiclient.DETAIL_LEVEL_DETAILED),
+				},
+			},
+			"disable_batching": schema.BoolAttribute{
+				Description: "Prevent the transactions of write requests from being bundled with others by default",
+				Optional:    true,
+			},
+			"transaction_mode": schema.BoolAttribute{
+				Description: "Commit the writes of resources submitted within the transaction batch window in a single EDA transaction, " +
+					"which succeeds or fails as a whole. Can not be combined with detail_level nor disable_batching",
+				Optional: true,
+			},
+			"transaction_dry_run": schema.BoolAttribute{
+				Description: "Run the transactions of the transaction mode as dry runs, which validate the writes without committing them",
+				Optional:    true,
+			},
+			"transaction_batch_window": schema.StringAttribute{
+				Description: "How long writes are collected before they are committed in a single transaction, in transaction mode",
+				Optional:    true,
+			},
+			"plan_validation": schema.BoolAttribute{
+				Description: "Validate planned resources against EDA with a dry run transaction, so that invalid resources fail at plan time",
+				Optional:    true,
+			},
+			"alarm_warnings": schema.BoolAttribute{
+				Description: "Raise warnings when refreshed resources have active alarms or deviations",
+				Optional:    true,
+			},
+			"alarm_error_severity": schema.StringAttribute{
+				Description: "Raise errors when planning no change to resources with active alarms of this severity or above, warnings otherwise, " +
+					"one of 'warning', 'minor', 'major' or 'critical'",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(alarmSeverities...),
+				},
+			},
+			"deviation_errors": schema.BoolAttribute{
+				Description: "Raise errors when planning no change to resources with deviations, warnings otherwise",
+				Optional:    true,
+			},
+			"max_requests_per_second": schema.Float64Attribute{
+				Description: "Maximum rate of API requests, with bursts of up to one second worth of requests, unlimited if 0",
+				Optional:    true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "Maximum number of API requests in flight, unlimited if 0",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"proxy_url": schema.StringAttribute{
+				Description: "URL of the HTTP(S) proxy the EDA API is reached through, instead of the HTTPS_PROXY and HTTP_PROXY environment variables",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"no_proxy": schema.StringAttribute{
+				Description: "Comma separated hosts, domains and CIDRs reached without the proxy, in the NO_PROXY format",
+				Optional:    true,
+			},
+			"max_idle_conns": schema.Int64Attribute{
+				Description: "Maximum number of idle connections kept open to the EDA API",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"idle_conn_timeout": schema.StringAttribute{
+				Description: "How long idle connections to the EDA API are kept open",
 				Optional:    true,
 			},
 		},
@@ -159,6 +416,23 @@ func (p *vmwareProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
+	for _, attrName := range durationAttributes {
+		key := tfutils.SnakeToCamel(attrName)
+		val, ok := anyData[key].(string)
+		if !ok {
+			continue
+		}
+		dur, err := time.ParseDuration(val)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(attrName), "Invalid duration", err.Error())
+			continue
+		}
+		anyData[key] = dur
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	config := apiclient.Config{}
 	err = utils.Convert(anyData, &config)
 	if err != nil {
@@ -170,10 +444,15 @@ func (p *vmwareProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = tfutils.MaskSensitiveFields(ctx, utils.NewSensitiveKeySet(config.LogMaskedKeys...))
 	tflog.Info(ctx, "Configure()::Provider config", map[string]any{"config": config.String()})
 
 	// Create a new EDA ApiService client using the configuration values
 	client, err := apiclient.NewEdaApiClient(ctx, &config)
+	if isCancelled(err) {
+		addCancelledDiagnostic(&resp.Diagnostics, "Unable to Create EDA API Client", err)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create EDA API Client",
@@ -186,19 +465,44 @@ func (p *vmwareProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	// Make the EDA API client available during DataSource and Resource type Configure methods.
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.EphemeralResourceData = client
 
 	tflog.Info(ctx, "Configured EDA API client", map[string]any{"success": true})
 }
 
+// Returns the API client configured by the provider, given as provider data to the Configure
+// method of a data source or resource of the given kind, e.g. "Data Source". Returns nil
+// when the provider is not configured yet, or when the provider data is not a client.
+func providerClient(providerData any, diags *diag.Diagnostics, kind string) *apiclient.EdaApiClient {
+	// Terraform sets the provider data after it calls the ConfigureProvider RPC
+	if providerData == nil {
+		return nil
+	}
+	client, ok := providerData.(*apiclient.EdaApiClient)
+	if !ok {
+		diags.AddError(
+			fmt.Sprintf("Unexpected %s Configure Type", kind),
+			fmt.Sprintf("Expected *api.EdaApiClient, got: %T. Please report this issue to the provider developers.", providerData),
+		)
+		return nil
+	}
+	return client
+}
+
 func validate(diags *diag.Diagnostics, cfg *apiclient.Config) {
 	if cfg.BaseURL == "" {
 		cfg.BaseURL = utils.GetEnvWithDefault(ENV_EDA_BASE_URL, "")
 	}
-	if cfg.BaseURL == "" {
+	if len(cfg.BaseURLs) == 0 {
+		if urls := utils.GetEnvWithDefault(ENV_EDA_BASE_URLS, ""); urls != "" {
+			cfg.BaseURLs = strings.Split(urls, ",")
+		}
+	}
+	if len(cfg.Endpoints()) == 0 {
 		diags.AddAttributeError(
 			path.Root("base_url"), "Unknown EDA Base URL",
 			"The provider cannot create the EDA API client as there is an unknown configuration value for the EDA Base URL. "+
-				"Either set the value statically in the configuration, or use the EDA_BASE_URL environment variable.")
+				"Either set base_url or base_urls statically in the configuration, or use the BASE_URL or BASE_URLS environment variables.")
 	}
 	if cfg.KcUsername == "" {
 		cfg.KcUsername = utils.GetEnvWithDefault(ENV_KC_USERNAME, DEF_USERNAME)
@@ -242,6 +546,281 @@ func validate(diags *diag.Diagnostics, cfg *apiclient.Config) {
 	if cfg.RestRetryInterval == 0*time.Second {
 		cfg.RestRetryInterval = utils.GetEnvDurationWithDefault(ENV_REST_RETRY_INTERVAL, DEF_REST_RETRY_INTERVAL)
 	}
+	if cfg.WaitForReady == false {
+		cfg.WaitForReady = utils.GetEnvBoolWithDefault(ENV_WAIT_FOR_READY, false)
+	}
+	if cfg.ReadyPollInterval == 0*time.Second {
+		cfg.ReadyPollInterval = utils.GetEnvDurationWithDefault(ENV_READY_POLL_INTERVAL, DEF_READY_POLL_INTERVAL)
+	}
+	validateReadiness(diags, cfg)
+	if cfg.TokenRefreshMargin == 0*time.Second {
+		cfg.TokenRefreshMargin = utils.GetEnvDurationWithDefault(ENV_TOKEN_REFRESH_MARGIN, DEF_TOKEN_REFRESH_MARGIN)
+	}
+	if cfg.TokenRefreshMargin <= 0 {
+		diags.AddAttributeError(
+			path.Root("token_refresh_margin"), "Invalid Token Refresh Margin",
+			"The token refresh margin must be positive, got: "+cfg.TokenRefreshMargin.String()+". "+
+				"Either set the value statically in the configuration, or use the TOKEN_REFRESH_MARGIN environment variable.")
+	}
+	if len(cfg.LogMaskedKeys) == 0 {
+		if keys := utils.GetEnvWithDefault(ENV_LOG_MASKED_KEYS, ""); keys != "" {
+			cfg.LogMaskedKeys = strings.Split(keys, ",")
+		}
+	}
+	validateAuth(diags, cfg)
+	validateTls(diags, cfg)
+	if cfg.DisableBatching == false {
+		cfg.DisableBatching = utils.GetEnvBoolWithDefault(ENV_DISABLE_BATCHING, false)
+	}
+	if cfg.TransactionMode == false {
+		cfg.TransactionMode = utils.GetEnvBoolWithDefault(ENV_TRANSACTION_MODE, false)
+	}
+	if cfg.TransactionDryRun == false {
+		cfg.TransactionDryRun = utils.GetEnvBoolWithDefault(ENV_TRANSACTION_DRY_RUN, false)
+	}
+	if cfg.TransactionBatchWindow == 0*time.Second {
+		cfg.TransactionBatchWindow = utils.GetEnvDurationWithDefault(ENV_TRANSACTION_BATCH_WINDOW, DEF_TRANSACTION_BATCH_WINDOW)
+	}
+	if cfg.TransactionBatchWindow <= 0 {
+		diags.AddAttributeError(
+			path.Root("transaction_batch_window"), "Invalid Transaction Batch Window",
+			"The transaction batch window must be positive, got: "+cfg.TransactionBatchWindow.String()+". "+
+				"Either set the value statically in the configuration, or use the TRANSACTION_BATCH_WINDOW environment variable.")
+	}
+	if cfg.PlanValidation == false {
+		cfg.PlanValidation = utils.GetEnvBoolWithDefault(ENV_PLAN_VALIDATION, false)
+	}
+	if cfg.AlarmWarnings == false {
+		cfg.AlarmWarnings = utils.GetEnvBoolWithDefault(ENV_ALARM_WARNINGS, false)
+	}
+	if cfg.DeviationErrors == false {
+		cfg.DeviationErrors = utils.GetEnvBoolWithDefault(ENV_DEVIATION_ERRORS, false)
+	}
+	if cfg.AlarmErrorSeverity == "" {
+		cfg.AlarmErrorSeverity = utils.GetEnvWithDefault(ENV_ALARM_ERROR_SEVERITY, "")
+	}
+	if cfg.AlarmErrorSeverity != "" && !slices.Contains(alarmSeverities, cfg.AlarmErrorSeverity) {
+		diags.AddAttributeError(
+			path.Root("alarm_error_severity"), "Invalid Alarm Error Severity",
+			"The alarm error severity must be one of '"+strings.Join(alarmSeverities, "', '")+"', got: "+cfg.AlarmErrorSeverity+". "+
+				"Either set the value statically in the configuration, or use the ALARM_ERROR_SEVERITY environment variable.")
+	}
+	if cfg.MaxRequestsPerSecond == 0 {
+		cfg.MaxRequestsPerSecond = utils.GetEnvFloatWithDefault(ENV_MAX_REQUESTS_PER_SECOND, 0)
+	}
+	if cfg.MaxRequestsPerSecond < 0 {
+		diags.AddAttributeError(
+			path.Root("max_requests_per_second"), "Invalid Max Requests Per Second",
+			"The maximum rate of API requests cannot be negative. "+
+				"Either set the value statically in the configuration, or use the MAX_REQUESTS_PER_SECOND environment variable.")
+	}
+	if cfg.MaxConcurrentRequests == 0 {
+		cfg.MaxConcurrentRequests = utils.GetEnvIntWithDefault(ENV_MAX_CONCURRENT_REQUESTS, 0)
+	}
+	if cfg.MaxConcurrentRequests < 0 {
+		diags.AddAttributeError(
+			path.Root("max_concurrent_requests"), "Invalid Max Concurrent Requests",
+			"The maximum number of API requests in flight cannot be negative. "+
+				"Either set the value statically in the configuration, or use the MAX_CONCURRENT_REQUESTS environment variable.")
+	}
+	validateTransport(diags, cfg)
+	if cfg.DetailLevel == "" {
+		cfg.DetailLevel = utils.GetEnvWithDefault(ENV_DETAIL_LEVEL, "")
+	}
+	if cfg.DetailLevel != "" && cfg.DetailLevel != apiclient.DETAIL_LEVEL_STANDARD && cfg.DetailLevel != apiclient.DETAIL_LEVEL_DETAILED {
+		diags.AddAttributeError(
+			path.Root("detail_level"), "Invalid Detail Level",
+			"The detail level must be either '"+apiclient.DETAIL_LEVEL_STANDARD+"' or '"+apiclient.DETAIL_LEVEL_DETAILED+"', got: "+cfg.DetailLevel+". "+
+				"Either set the value statically in the configuration, or use the DETAIL_LEVEL environment variable.")
+	}
+	validateTransactionMode(diags, cfg)
+	if cfg.UpdateMethod == "" {
+		cfg.UpdateMethod = utils.GetEnvWithDefault(ENV_UPDATE_METHOD, DEF_UPDATE_METHOD)
+	}
+	if cfg.UpdateMethod != apiclient.UPDATE_METHOD_PATCH && cfg.UpdateMethod != apiclient.UPDATE_METHOD_PUT {
+		diags.AddAttributeError(
+			path.Root("update_method"), "Invalid Update Method",
+			"The update method must be either '"+apiclient.UPDATE_METHOD_PATCH+"' or '"+apiclient.UPDATE_METHOD_PUT+"', got: "+cfg.UpdateMethod+". "+
+				"Either set the value statically in the configuration, or use the UPDATE_METHOD environment variable.")
+	}
+}
+
+// Rejects the write options in transaction mode, as transactions have no write options
+func validateTransactionMode(diags *diag.Diagnostics, cfg *apiclient.Config) {
+	if cfg.TransactionMode && (cfg.DetailLevel != "" || cfg.DisableBatching) {
+		diags.AddAttributeError(
+			path.Root("transaction_mode"), "Write Options Not Supported In Transaction Mode",
+			"Writes are committed in transactions in transaction mode, which do not support the detail level "+
+				"nor disabling batching. Either unset detail_level and disable_batching (or the DETAIL_LEVEL and "+
+				"DISABLE_BATCHING environment variables), or disable transaction_mode.")
+	}
+}
+
+func validateReadiness(diags *diag.Diagnostics, cfg *apiclient.Config) {
+	if cfg.ReadyStatusField == "" {
+		cfg.ReadyStatusField = utils.GetEnvWithDefault(ENV_READY_STATUS_FIELD, "")
+	}
+	if len(cfg.ReadyStatusValues) == 0 {
+		if values := utils.GetEnvWithDefault(ENV_READY_STATUS_VALUES, ""); values != "" {
+			cfg.ReadyStatusValues = strings.Split(values, ",")
+		}
+	}
+	if len(cfg.FailedStatusValues) == 0 {
+		if values := utils.GetEnvWithDefault(ENV_FAILED_STATUS_VALUES, ""); values != "" {
+			cfg.FailedStatusValues = strings.Split(values, ",")
+		}
+	}
+	if cfg.ReadyPollInterval <= 0 {
+		diags.AddAttributeError(
+			path.Root("ready_poll_interval"), "Invalid Ready Poll Interval",
+			"The interval between status polls must be positive, got: "+cfg.ReadyPollInterval.String()+". "+
+				"Either set the value statically in the configuration, or use the READY_POLL_INTERVAL environment variable.")
+	}
+	if !cfg.WaitForReady {
+		return
+	}
+	if cfg.ReadyStatusField == "" {
+		diags.AddAttributeError(
+			path.Root("ready_status_field"), "Unknown Ready Status Field",
+			"Waiting for resources to become ready requires the status field reporting their state. "+
+				"Either set the value statically in the configuration, or use the READY_STATUS_FIELD environment variable.")
+	}
+	if len(cfg.ReadyStatusValues) == 0 {
+		diags.AddAttributeError(
+			path.Root("ready_status_values"), "Unknown Ready Status Values",
+			"Waiting for resources to become ready requires the values of the status field meaning ready. "+
+				"Either set the value statically in the configuration, or use the READY_STATUS_VALUES environment variable.")
+	}
+}
+
+func validateTransport(diags *diag.Diagnostics, cfg *apiclient.Config) {
+	if cfg.ProxyURL == "" {
+		cfg.ProxyURL = utils.GetEnvWithDefault(ENV_PROXY_URL, "")
+	}
+	if cfg.NoProxy == "" {
+		cfg.NoProxy = utils.GetEnvWithDefault(ENV_NO_PROXY, "")
+	}
+	if cfg.MaxIdleConns == 0 {
+		cfg.MaxIdleConns = utils.GetEnvIntWithDefault(ENV_MAX_IDLE_CONNS, 0)
+	}
+	if cfg.IdleConnTimeout == 0*time.Second {
+		cfg.IdleConnTimeout = utils.GetEnvDurationWithDefault(ENV_IDLE_CONN_TIMEOUT, 0)
+	}
+	if cfg.ProxyURL != "" {
+		proxyUrl, err := url.Parse(cfg.ProxyURL)
+		if err != nil || proxyUrl.Host == "" || !slices.Contains([]string{"http", "https", "socks5"}, proxyUrl.Scheme) {
+			diags.AddAttributeError(
+				path.Root("proxy_url"), "Invalid Proxy URL",
+				"The proxy URL must be an absolute http, https or socks5 URL like 'http://proxy.example.com:3128'. "+
+					"Either set the value statically in the configuration, or use the PROXY_URL environment variable.")
+		}
+	}
+	// An idle connection timeout of 0 keeps the transport default
+	if cfg.IdleConnTimeout < 0 {
+		diags.AddAttributeError(
+			path.Root("idle_conn_timeout"), "Invalid Idle Connection Timeout",
+			"The idle connection timeout cannot be negative, got: "+cfg.IdleConnTimeout.String()+". "+
+				"Either set the value statically in the configuration, or use the IDLE_CONN_TIMEOUT environment variable.")
+	}
+	if cfg.MaxIdleConns < 0 {
+		diags.AddAttributeError(
+			path.Root("max_idle_conns"), "Invalid Max Idle Connections",
+			"The maximum number of idle connections cannot be negative. "+
+				"Either set the value statically in the configuration, or use the MAX_IDLE_CONNS environment variable.")
+	}
+}
+
+func validateAuth(diags *diag.Diagnostics, cfg *apiclient.Config) {
+	if cfg.AuthMode == "" {
+		cfg.AuthMode = utils.GetEnvWithDefault(ENV_AUTH_MODE, DEF_AUTH_MODE)
+	}
+	if cfg.AccessToken == "" {
+		cfg.AccessToken = utils.GetEnvWithDefault(ENV_ACCESS_TOKEN, "")
+	}
+	if cfg.AccessTokenFile == "" {
+		cfg.AccessTokenFile = utils.GetEnvWithDefault(ENV_ACCESS_TOKEN_FILE, "")
+	}
+	if cfg.AccessTokenCommand == "" {
+		cfg.AccessTokenCommand = utils.GetEnvWithDefault(ENV_ACCESS_TOKEN_COMMAND, "")
+	}
+	switch cfg.AuthMode {
+	case apiclient.AUTH_MODE_PASSWORD:
+	case apiclient.AUTH_MODE_CLIENT_CREDENTIALS:
+		if cfg.EdaClientSecret == "" {
+			diags.AddAttributeError(
+				path.Root("client_secret"), "Missing EDA Client Secret",
+				"The '"+apiclient.AUTH_MODE_CLIENT_CREDENTIALS+"' auth mode requires the secret of the service account client. "+
+					"Either set the value statically in the configuration, or use the CLIENT_SECRET environment variable.")
+		}
+	case apiclient.AUTH_MODE_TOKEN:
+		sources := 0
+		for _, src := range []string{cfg.AccessToken, cfg.AccessTokenFile, cfg.AccessTokenCommand} {
+			if src != "" {
+				sources++
+			}
+		}
+		if sources != 1 {
+			diags.AddAttributeError(
+				path.Root("access_token"), "Invalid Access Token Configuration",
+				"The '"+apiclient.AUTH_MODE_TOKEN+"' auth mode requires exactly one of access_token, access_token_file or access_token_command. "+
+					"Either set the value statically in the configuration, or use the ACCESS_TOKEN, ACCESS_TOKEN_FILE or ACCESS_TOKEN_COMMAND environment variables.")
+		}
+	default:
+		diags.AddAttributeError(
+			path.Root("auth_mode"), "Invalid Auth Mode",
+			"The auth mode must be one of '"+apiclient.AUTH_MODE_PASSWORD+"', '"+apiclient.AUTH_MODE_CLIENT_CREDENTIALS+"' or '"+
+				apiclient.AUTH_MODE_TOKEN+"', got: "+cfg.AuthMode+". "+
+				"Either set the value statically in the configuration, or use the AUTH_MODE environment variable.")
+	}
+}
+
+func validateTls(diags *diag.Diagnostics, cfg *apiclient.Config) {
+	if cfg.CaCert == "" {
+		cfg.CaCert = utils.GetEnvWithDefault(ENV_CA_CERT, "")
+	}
+	if cfg.CaCertFile == "" {
+		cfg.CaCertFile = utils.GetEnvWithDefault(ENV_CA_CERT_FILE, "")
+	}
+	if cfg.ClientCert == "" {
+		cfg.ClientCert = utils.GetEnvWithDefault(ENV_CLIENT_CERT, "")
+	}
+	if cfg.ClientKey == "" {
+		cfg.ClientKey = utils.GetEnvWithDefault(ENV_CLIENT_KEY, "")
+	}
+	if cfg.TlsServerName == "" {
+		cfg.TlsServerName = utils.GetEnvWithDefault(ENV_TLS_SERVER_NAME, "")
+	}
+
+	if _, err := apiclient.ReadCaCerts(&apiclient.Config{CaCert: cfg.CaCert}); err != nil {
+		diags.AddAttributeError(
+			path.Root("ca_cert"), "Invalid CA Certificate",
+			"The CA certificate must hold one or more PEM encoded certificates: "+err.Error()+". "+
+				"Either set the value statically in the configuration, or use the CA_CERT environment variable.")
+	}
+	if _, err := apiclient.ReadCaCerts(&apiclient.Config{CaCertFile: cfg.CaCertFile}); err != nil {
+		diags.AddAttributeError(
+			path.Root("ca_cert_file"), "Invalid CA Certificate File",
+			"The CA certificate file must be readable and hold one or more PEM encoded certificates: "+err.Error()+". "+
+				"Either set the value statically in the configuration, or use the CA_CERT_FILE environment variable.")
+	}
+	switch {
+	case cfg.ClientCert != "" && cfg.ClientKey == "":
+		diags.AddAttributeError(
+			path.Root("client_key"), "Missing Client Key",
+			"The client key is required with a client certificate. "+
+				"Either set the value statically in the configuration, or use the CLIENT_KEY environment variable.")
+	case cfg.ClientCert == "" && cfg.ClientKey != "":
+		diags.AddAttributeError(
+			path.Root("client_cert"), "Missing Client Certificate",
+			"The client certificate is required with a client key. "+
+				"Either set the value statically in the configuration, or use the CLIENT_CERT environment variable.")
+	case cfg.ClientCert != "":
+		if _, err := tls.X509KeyPair([]byte(cfg.ClientCert), []byte(cfg.ClientKey)); err != nil {
+			diags.AddAttributeError(
+				path.Root("client_cert"), "Invalid Client Certificate",
+				"The client certificate and key must be a matching pair of PEM encoded certificate and private key: "+err.Error())
+		}
+	}
 }
 
 func (p *vmwareProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -255,11 +834,23 @@ func (p *vmwareProvider) DataSources(ctx context.Context) []func() datasource.Da
 		NewResourceListDataSource,
 		NewVmwarePluginInstanceDataSource,
 		NewVmwarePluginInstanceListDataSource,
+		NewVmwarePluginInstanceHistoryDataSource,
+		NewVmwarePluginInstanceDeletedListDataSource,
+		NewVmwarePluginInstanceTargetsDataSource,
+		NewVmwarePluginInstanceTopologyDataSource,
+		NewVmwarePluginInstanceAlarmsDataSource,
 	}
 }
 
 func (p *vmwareProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewVmwarePluginInstanceResource,
+		NewVmwarePluginInstancePurgeResource,
+	}
+}
+
+func (p *vmwareProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewVmwarePluginInstanceHistoryEphemeralResource,
 	}
 }
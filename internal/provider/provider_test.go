@@ -0,0 +1,273 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/eda/apiclient"
+)
+
+// Clears the environment variables the provider settings default to
+func clearProviderEnv(t *testing.T, keys ...string) {
+	for _, key := range keys {
+		t.Setenv(key, "")
+	}
+}
+
+// Returns the paths of the attribute errors in diags, and the number of other errors
+func errorPaths(diags diag.Diagnostics) ([]path.Path, int) {
+	var paths []path.Path
+	others := 0
+	for _, d := range diags.Errors() {
+		if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+			paths = append(paths, withPath.Path())
+		} else {
+			others++
+		}
+	}
+	return paths, others
+}
+
+func TestValidateAuth(t *testing.T) {
+	clearProviderEnv(t, ENV_AUTH_MODE, ENV_ACCESS_TOKEN, ENV_ACCESS_TOKEN_FILE, ENV_ACCESS_TOKEN_COMMAND)
+	tests := []struct {
+		name     string
+		cfg      apiclient.Config
+		expected []path.Path
+	}{
+		{
+			name: "password by default",
+			cfg:  apiclient.Config{},
+		},
+		{
+			name:     "invalid auth mode",
+			cfg:      apiclient.Config{AuthMode: "kerberos"},
+			expected: []path.Path{path.Root("auth_mode")},
+		},
+		{
+			name: "client credentials",
+			cfg:  apiclient.Config{AuthMode: apiclient.AUTH_MODE_CLIENT_CREDENTIALS, EdaClientSecret: "secret"},
+		},
+		{
+			name:     "client credentials without secret",
+			cfg:      apiclient.Config{AuthMode: apiclient.AUTH_MODE_CLIENT_CREDENTIALS},
+			expected: []path.Path{path.Root("client_secret")},
+		},
+		{
+			name: "token from file",
+			cfg:  apiclient.Config{AuthMode: apiclient.AUTH_MODE_TOKEN, AccessTokenFile: "/run/secrets/eda-token"},
+		},
+		{
+			name:     "token without source",
+			cfg:      apiclient.Config{AuthMode: apiclient.AUTH_MODE_TOKEN},
+			expected: []path.Path{path.Root("access_token")},
+		},
+		{
+			name:     "token with several sources",
+			cfg:      apiclient.Config{AuthMode: apiclient.AUTH_MODE_TOKEN, AccessToken: "token", AccessTokenCommand: "vault read eda"},
+			expected: []path.Path{path.Root("access_token")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validateAuth(&diags, &tt.cfg)
+			paths, others := errorPaths(diags)
+			if others > 0 || len(paths) != len(tt.expected) {
+				t.Fatalf("validateAuth() errors = %v, want errors on %v", diags.Errors(), tt.expected)
+			}
+			for i, p := range paths {
+				if !p.Equal(tt.expected[i]) {
+					t.Errorf("validateAuth() error on %s, want %s", p, tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+// Returns a PEM encoded self-signed certificate with the given common name, and its PEM encoded key
+func newTestCertificate(t *testing.T, commonName string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer}))
+}
+
+func TestValidateTls(t *testing.T) {
+	clearProviderEnv(t, ENV_CA_CERT, ENV_CA_CERT_FILE, ENV_CLIENT_CERT, ENV_CLIENT_KEY, ENV_TLS_SERVER_NAME)
+	caCert, _ := newTestCertificate(t, "eda.local")
+	clientCert, clientKey := newTestCertificate(t, "terraform")
+	_, otherKey := newTestCertificate(t, "other")
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte(caCert), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		cfg      apiclient.Config
+		expected []path.Path
+	}{
+		{
+			name: "no TLS settings",
+			cfg:  apiclient.Config{},
+		},
+		{
+			name: "valid certificates",
+			cfg:  apiclient.Config{CaCert: caCert, CaCertFile: caFile, ClientCert: clientCert, ClientKey: clientKey},
+		},
+		{
+			name:     "bad CA certificate",
+			cfg:      apiclient.Config{CaCert: "-----BEGIN CERTIFICATE-----\nnot base64\n"},
+			expected: []path.Path{path.Root("ca_cert")},
+		},
+		{
+			name:     "missing CA certificate file",
+			cfg:      apiclient.Config{CaCertFile: filepath.Join(t.TempDir(), "missing.pem")},
+			expected: []path.Path{path.Root("ca_cert_file")},
+		},
+		{
+			name:     "client certificate without key",
+			cfg:      apiclient.Config{ClientCert: clientCert},
+			expected: []path.Path{path.Root("client_key")},
+		},
+		{
+			name:     "client key without certificate",
+			cfg:      apiclient.Config{ClientKey: clientKey},
+			expected: []path.Path{path.Root("client_cert")},
+		},
+		{
+			name:     "mismatched client key",
+			cfg:      apiclient.Config{ClientCert: clientCert, ClientKey: otherKey},
+			expected: []path.Path{path.Root("client_cert")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validateTls(&diags, &tt.cfg)
+			paths, others := errorPaths(diags)
+			if others > 0 || len(paths) != len(tt.expected) {
+				t.Fatalf("validateTls() errors = %v, want errors on %v", diags.Errors(), tt.expected)
+			}
+			for i, p := range paths {
+				if !p.Equal(tt.expected[i]) {
+					t.Errorf("validateTls() error on %s, want %s", p, tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateTransactionMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      apiclient.Config
+		expected []path.Path
+	}{
+		{
+			name: "transaction mode",
+			cfg:  apiclient.Config{TransactionMode: true},
+		},
+		{
+			name: "write options without transaction mode",
+			cfg:  apiclient.Config{DetailLevel: apiclient.DETAIL_LEVEL_DETAILED, DisableBatching: true},
+		},
+		{
+			name:     "detail level in transaction mode",
+			cfg:      apiclient.Config{TransactionMode: true, DetailLevel: apiclient.DETAIL_LEVEL_STANDARD},
+			expected: []path.Path{path.Root("transaction_mode")},
+		},
+		{
+			name:     "disable batching in transaction mode",
+			cfg:      apiclient.Config{TransactionMode: true, DisableBatching: true},
+			expected: []path.Path{path.Root("transaction_mode")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validateTransactionMode(&diags, &tt.cfg)
+			paths, others := errorPaths(diags)
+			if others > 0 || len(paths) != len(tt.expected) {
+				t.Fatalf("validateTransactionMode() errors = %v, want errors on %v", diags.Errors(), tt.expected)
+			}
+			for i, p := range paths {
+				if !p.Equal(tt.expected[i]) {
+					t.Errorf("validateTransactionMode() error on %s, want %s", p, tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateDurations(t *testing.T) {
+	clearProviderEnv(t, ENV_EDA_BASE_URL, ENV_READY_POLL_INTERVAL, ENV_TOKEN_REFRESH_MARGIN,
+		ENV_TRANSACTION_BATCH_WINDOW, ENV_IDLE_CONN_TIMEOUT)
+	tests := []struct {
+		name     string
+		cfg      apiclient.Config
+		expected []path.Path
+	}{
+		{
+			name: "defaults",
+			cfg:  apiclient.Config{},
+		},
+		{
+			name: "positive durations",
+			cfg: apiclient.Config{ReadyPollInterval: time.Second, TokenRefreshMargin: time.Minute,
+				TransactionBatchWindow: time.Millisecond, IdleConnTimeout: time.Minute},
+		},
+		{
+			name: "negative durations",
+			cfg: apiclient.Config{ReadyPollInterval: -5 * time.Second, TokenRefreshMargin: -time.Second,
+				TransactionBatchWindow: -time.Millisecond, IdleConnTimeout: -time.Minute},
+			expected: []path.Path{path.Root("ready_poll_interval"), path.Root("token_refresh_margin"),
+				path.Root("transaction_batch_window"), path.Root("idle_conn_timeout")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.BaseURL = "https://eda.example.com"
+			var diags diag.Diagnostics
+			validate(&diags, &tt.cfg)
+			paths, others := errorPaths(diags)
+			if others > 0 || len(paths) != len(tt.expected) {
+				t.Fatalf("validate() errors = %v, want errors on %v", diags.Errors(), tt.expected)
+			}
+			for i, p := range paths {
+				if !p.Equal(tt.expected[i]) {
+					t.Errorf("validate() error on %s, want %s", p, tt.expected[i])
+				}
+			}
+		})
+	}
+}
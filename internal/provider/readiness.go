@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/tfutils"
+)
+
+// readyCondition is the status field reporting the state of a resource, and the values of
+// that field meaning the resource is ready or has failed. The status schema of the API
+// does not document such a field, so it is configured by the user.
+type readyCondition struct {
+	// Path of the field in the status, with dots between the names of nested fields
+	field        string
+	readyValues  []string
+	failedValues []string
+}
+
+// Alarm severities, from the least to the most severe
+var alarmSeverities = []string{"warning", "minor", "major", "critical"}
+
+// readiness is the outcome of evaluating the status of a resource
+type readiness struct {
+	ready  bool
+	failed bool
+	reason string
+}
+
+// Evaluates the alarms, deviations and status returned by the API for a resource.
+// Critical or major alarms and any deviations are treated as a failure, otherwise the
+// resource is ready once the status field of cond holds one of its ready values.
+func checkReadiness(result map[string]any, cond readyCondition) readiness {
+	if alarms, ok := result["alarms"].(map[string]any); ok {
+		for _, severity := range []string{"critical", "major"} {
+			if count, err := tfutils.NumToInt64(alarms[severity]); err == nil && count > 0 {
+				return readiness{failed: true, reason: fmt.Sprintf("%d %s alarm(s) raised", count, severity)}
+			}
+		}
+	}
+	if deviations, ok := result["deviations"].(map[string]any); ok {
+		if count, err := tfutils.NumToInt64(deviations["count"]); err == nil && count > 0 {
+			return readiness{failed: true, reason: fmt.Sprintf("%d deviation(s) reported", count)}
+		}
+	}
+
+	var value any = result["status"]
+	for _, name := range strings.Split(cond.field, ".") {
+		fields, ok := value.(map[string]any)
+		if !ok {
+			value = nil
+			break
+		}
+		value = fields[name]
+	}
+	if value == nil {
+		return readiness{reason: "status." + cond.field + " not reported yet"}
+	}
+	state := fmt.Sprint(value)
+	isOneOf := func(values []string) bool {
+		return slices.ContainsFunc(values, func(v string) bool { return strings.EqualFold(v, state) })
+	}
+	switch {
+	case isOneOf(cond.readyValues):
+		return readiness{ready: true, reason: "status." + cond.field + " is " + state}
+	case isOneOf(cond.failedValues):
+		return readiness{failed: true, reason: "status." + cond.field + " is " + state}
+	default:
+		return readiness{reason: "status." + cond.field + " is " + state}
+	}
+}
+
+// Adds the diagnostics for the active alarms and deviations of a resource. Alarms at or
+// above errorSeverity, and deviations if deviationErrors is set, are errors when raiseErrors
+// is set, and warnings otherwise. Other alarms and deviations are warnings when warn is set.
+func addDriftDiagnostics(diags *diag.Diagnostics, resourceName string, result map[string]any,
+	warn bool, errorSeverity string, deviationErrors, raiseErrors bool) {
+	addError := diags.AddAttributeError
+	if !raiseErrors {
+		addError = diags.AddAttributeWarning
+	}
+	threshold := slices.Index(alarmSeverities, errorSeverity)
+	var errorCounts, warningCounts []string
+	if alarms, ok := result["alarms"].(map[string]any); ok {
+		for i := len(alarmSeverities) - 1; i >= 0; i-- {
+			severity := alarmSeverities[i]
+			count, err := tfutils.NumToInt64(alarms[severity])
+			if err != nil || count == 0 {
+				continue
+			}
+			if threshold >= 0 && i >= threshold {
+				errorCounts = append(errorCounts, fmt.Sprintf("%d %s", count, severity))
+			} else {
+				warningCounts = append(warningCounts, fmt.Sprintf("%d %s", count, severity))
+			}
+		}
+	}
+	const details = "Read the vmware_plugin_instance_alarms data source for the details."
+	if len(errorCounts) > 0 {
+		addError(path.Root("alarms"), "Active alarms on "+resourceName,
+			fmt.Sprintf("%s has %s alarm(s) at or above the %s severity. %s", resourceName, strings.Join(errorCounts, ", "), errorSeverity, details))
+	}
+	if len(warningCounts) > 0 && warn {
+		diags.AddAttributeWarning(path.Root("alarms"), "Active alarms on "+resourceName,
+			fmt.Sprintf("%s has %s alarm(s). %s", resourceName, strings.Join(warningCounts, ", "), details))
+	}
+
+	deviations, _ := result["deviations"].(map[string]any)
+	if count, err := tfutils.NumToInt64(deviations["count"]); err == nil && count > 0 {
+		summary := "Deviations on " + resourceName
+		detail := fmt.Sprintf("%s has %d deviation(s) from its intended configuration. %s", resourceName, count, details)
+		switch {
+		case deviationErrors:
+			addError(path.Root("deviations"), summary, detail)
+		case warn:
+			diags.AddAttributeWarning(path.Root("deviations"), summary, detail)
+		}
+	}
+}
+
+// Polls the resource at readPath until it meets cond, fails or the timeout expires.
+// The last result read from the API is returned, and is also used as the starting point.
+func waitForReady(ctx context.Context, get func(ctx context.Context, result *map[string]any) error,
+	readPath string, cond readyCondition, timeout, interval time.Duration, result map[string]any) (map[string]any, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	t0 := time.Now()
+	for {
+		r := checkReadiness(result, cond)
+		tflog.Info(ctx, "waitForReady()", map[string]any{
+			"path":        readPath,
+			"ready":       r.ready,
+			"failed":      r.failed,
+			"reason":      r.reason,
+			"timeElapsed": time.Since(t0).String(),
+		})
+		if r.ready {
+			return result, nil
+		}
+		if r.failed {
+			return result, fmt.Errorf("resource failed to become ready: %s\n\nLast observed status:\n%s",
+				r.reason, lastObservedStatus(result))
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.Canceled) {
+				return result, ctx.Err()
+			}
+			return result, fmt.Errorf("timed out after %s waiting for resource to become ready: %s\n\nLast observed status:\n%s",
+				timeout, r.reason, lastObservedStatus(result))
+		case <-ticker.C:
+		}
+
+		latest := map[string]any{}
+		if err := get(ctx, &latest); err != nil {
+			tflog.Warn(ctx, "waitForReady()::API read failed", map[string]any{
+				"path":  readPath,
+				"error": err.Error(),
+			})
+			continue
+		}
+		tflog.Debug(ctx, "waitForReady()::API returned", map[string]any{
+			"path":   readPath,
+			"result": tfutils.RedactedDump(ctx, latest),
+		})
+		result = latest
+	}
+}
+
+func lastObservedStatus(result map[string]any) string {
+	observed, err := json.MarshalIndent(map[string]any{
+		"status":     result["status"],
+		"alarms":     result["alarms"],
+		"deviations": result["deviations"],
+	}, "", "  ")
+	if err != nil {
+		return tfutils.RedactedDump(context.Background(), result["status"])
+	}
+	return string(observed)
+}
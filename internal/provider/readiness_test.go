@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestAddDriftDiagnostics(t *testing.T) {
+	result := map[string]any{
+		"alarms":     map[string]any{"critical": int64(1), "minor": int64(2)},
+		"deviations": map[string]any{"count": int64(3)},
+	}
+	tests := []struct {
+		name            string
+		warn            bool
+		deviationErrors bool
+		raiseErrors     bool
+		errors          int
+		warnings        int
+	}{
+		{name: "plan without change", warn: true, deviationErrors: true, raiseErrors: true, errors: 2, warnings: 1},
+		{name: "errors only", warn: false, deviationErrors: true, raiseErrors: true, errors: 2, warnings: 0},
+		{name: "refresh", warn: true, deviationErrors: true, raiseErrors: false, errors: 0, warnings: 3},
+		{name: "refresh without warnings", warn: false, deviationErrors: true, raiseErrors: false, errors: 0, warnings: 2},
+		{name: "deviation warnings", warn: true, deviationErrors: false, raiseErrors: true, errors: 1, warnings: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			addDriftDiagnostics(&diags, "VmwarePluginInstance vc1", result, tt.warn, "major", tt.deviationErrors, tt.raiseErrors)
+			if diags.ErrorsCount() != tt.errors || diags.WarningsCount() != tt.warnings {
+				t.Errorf("diagnostics = %v, want %d error(s) and %d warning(s)", diags, tt.errors, tt.warnings)
+			}
+		})
+	}
+}
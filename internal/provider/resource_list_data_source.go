@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/davecgh/go-spew/spew"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/datasource_resource_list"
@@ -37,6 +36,7 @@ func (d *resourceListDataSource) Schema(ctx context.Context, req datasource.Sche
 }
 
 func (d *resourceListDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = tfutils.MaskSensitiveFields(ctx, d.client.SensitiveKeys())
 	var data datasource_resource_list.ResourceListModel
 
 	// Read Terraform prior state data into the model
@@ -56,7 +56,7 @@ func (d *resourceListDataSource) Read(ctx context.Context, req datasource.ReadRe
 	// Read API call logic
 	tflog.Info(ctx, "Read()::API request", map[string]any{
 		"path":  read_ds_resourceList,
-		"data":  spew.Sdump(data),
+		"data":  tfutils.RedactedDump(ctx, &data),
 		"query": queryParams,
 	})
 
@@ -66,12 +66,12 @@ func (d *resourceListDataSource) Read(ctx context.Context, req datasource.ReadRe
 
 	tflog.Info(ctx, "Read()::API returned", map[string]any{
 		"path":      read_ds_resourceList,
-		"result":    spew.Sdump(result),
+		"result":    tfutils.RedactedDump(ctx, result),
 		"timeTaken": time.Since(t0).String(),
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading resource", err.Error())
+		addApiErrorDiagnostics(&resp.Diagnostics, "Error reading resource", err)
 		return
 	}
 
@@ -0,0 +1,368 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/eda/apiclient"
+	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/tfutils"
+)
+
+const (
+	// EQL tables of the active alarms and of the deviations of a namespace. Alarm rows hold
+	// the fields of an AlarmData, and deviation rows a Deviation resource.
+	alarmsQueryTable     = ".namespace.alarms.v1.current-alarm"
+	deviationsQueryTable = ".namespace.resources.cr.core_eda_nokia_com.v1.deviation"
+)
+
+var (
+	_ datasource.DataSource              = (*vmwarePluginInstanceAlarmsDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*vmwarePluginInstanceAlarmsDataSource)(nil)
+)
+
+var (
+	alarmAttrTypes = map[string]attr.Type{
+		"name":           types.StringType,
+		"severity":       types.StringType,
+		"type":           types.StringType,
+		"text":           types.StringType,
+		"probable_cause": types.StringType,
+		"acknowledged":   types.BoolType,
+		"last_changed":   types.StringType,
+	}
+	deviationAttrTypes = map[string]attr.Type{
+		"name":      types.StringType,
+		"namespace": types.StringType,
+		"node":      types.StringType,
+		"path":      types.StringType,
+	}
+)
+
+func NewVmwarePluginInstanceAlarmsDataSource() datasource.DataSource {
+	return &vmwarePluginInstanceAlarmsDataSource{}
+}
+
+type vmwarePluginInstanceAlarmsDataSource struct {
+	client *apiclient.EdaApiClient
+}
+
+type vmwarePluginInstanceAlarmsModel struct {
+	Name           types.String `tfsdk:"name"`
+	Namespace      types.String `tfsdk:"namespace"`
+	CriticalAlarms types.Int64  `tfsdk:"critical_alarms"`
+	MajorAlarms    types.Int64  `tfsdk:"major_alarms"`
+	MinorAlarms    types.Int64  `tfsdk:"minor_alarms"`
+	WarningAlarms  types.Int64  `tfsdk:"warning_alarms"`
+	DeviationCount types.Int64  `tfsdk:"deviation_count"`
+	Alarms         types.List   `tfsdk:"alarms"`
+	Deviations     types.List   `tfsdk:"deviations"`
+}
+
+// Alarm and deviation details, as stored in the Terraform state
+type instanceAlarm struct {
+	Name          string `tfsdk:"name"`
+	Severity      string `tfsdk:"severity"`
+	Type          string `tfsdk:"type"`
+	Text          string `tfsdk:"text"`
+	ProbableCause string `tfsdk:"probable_cause"`
+	Acknowledged  bool   `tfsdk:"acknowledged"`
+	LastChanged   string `tfsdk:"last_changed"`
+}
+
+type instanceDeviation struct {
+	Name      string `tfsdk:"name"`
+	Namespace string `tfsdk:"namespace"`
+	Node      string `tfsdk:"node"`
+	Path      string `tfsdk:"path"`
+}
+
+func (d *vmwarePluginInstanceAlarmsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vmware_plugin_instance_alarms"
+}
+
+func (d *vmwarePluginInstanceAlarmsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Active alarms and deviations of a VmwarePluginInstance",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "name of the VmwarePluginInstance",
+			},
+			"namespace": schema.StringAttribute{
+				Optional:    true,
+				Description: "namespace of the VmwarePluginInstance",
+			},
+			"critical_alarms": schema.Int64Attribute{
+				Computed:    true,
+				Description: "number of active critical alarms reported for the VmwarePluginInstance",
+			},
+			"major_alarms": schema.Int64Attribute{
+				Computed:    true,
+				Description: "number of active major alarms reported for the VmwarePluginInstance",
+			},
+			"minor_alarms": schema.Int64Attribute{
+				Computed:    true,
+				Description: "number of active minor alarms reported for the VmwarePluginInstance",
+			},
+			"warning_alarms": schema.Int64Attribute{
+				Computed:    true,
+				Description: "number of active warning alarms reported for the VmwarePluginInstance",
+			},
+			"deviation_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "number of deviations reported for the VmwarePluginInstance",
+			},
+			"alarms": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "active alarms raised against the VmwarePluginInstance, most severe first",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name":           schema.StringAttribute{Computed: true},
+						"severity":       schema.StringAttribute{Computed: true},
+						"type":           schema.StringAttribute{Computed: true},
+						"text":           schema.StringAttribute{Computed: true},
+						"probable_cause": schema.StringAttribute{Computed: true},
+						"acknowledged":   schema.BoolAttribute{Computed: true},
+						"last_changed":   schema.StringAttribute{Computed: true},
+					},
+				},
+			},
+			"deviations": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "deviations from the intended configuration on the targets of the VmwarePluginInstance",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name":      schema.StringAttribute{Computed: true},
+						"namespace": schema.StringAttribute{Computed: true},
+						"node":      schema.StringAttribute{Computed: true},
+						"path":      schema.StringAttribute{Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *vmwarePluginInstanceAlarmsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = tfutils.MaskSensitiveFields(ctx, d.client.SensitiveKeys())
+	var data vmwarePluginInstanceAlarmsModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.Name.ValueString()
+	namespace := tfutils.StringValue(data.Namespace)
+
+	// Read API call logic
+	tflog.Info(ctx, "Read()::API request", map[string]any{
+		"path": read_rs_vmwarePluginInstance,
+		"data": tfutils.RedactedDump(ctx, &data),
+	})
+
+	t0 := time.Now()
+	instance := map[string]any{}
+	readPath, pathParams := apiclient.NamespacedPath(read_rs_vmwarePluginInstance, map[string]string{"name": name}, namespace)
+	err := d.client.Get(ctx, readPath, pathParams, &instance)
+	if err != nil {
+		addApiErrorDiagnostics(&resp.Diagnostics, "Error reading VmwarePluginInstance", err)
+		return
+	}
+
+	// The deviations are reported against the nodes targeted by the instance
+	targets := []map[string]any{}
+	targetsPath, pathParams := apiclient.NamespacedPath(read_ds_vmwarePluginInstanceTargets, map[string]string{"name": name}, namespace)
+	err = d.client.Get(ctx, targetsPath, pathParams, &targets)
+	if err != nil {
+		addApiErrorDiagnostics(&resp.Diagnostics, "Error reading intent targets", err)
+		return
+	}
+
+	alarmRows, err := d.client.Query(ctx, alarmsQuery(name), namespace)
+	if err != nil {
+		addApiErrorDiagnostics(&resp.Diagnostics, "Error querying alarms", err)
+		return
+	}
+	deviationRows := []map[string]any{}
+	if len(targets) > 0 {
+		deviationRows, err = d.client.Query(ctx, deviationsQuery(targets), namespace)
+		if err != nil {
+			addApiErrorDiagnostics(&resp.Diagnostics, "Error querying deviations", err)
+			return
+		}
+	}
+
+	tflog.Info(ctx, "Read()::API returned", map[string]any{
+		"path":       readPath,
+		"alarms":     tfutils.RedactedDump(ctx, instance["alarms"]),
+		"deviations": tfutils.RedactedDump(ctx, instance["deviations"]),
+		"timeTaken":  time.Since(t0).String(),
+	})
+
+	// Convert API response to Terraform model
+	alarmCounts, _ := instance["alarms"].(map[string]any)
+	data.CriticalAlarms = countValue(alarmCounts, "critical")
+	data.MajorAlarms = countValue(alarmCounts, "major")
+	data.MinorAlarms = countValue(alarmCounts, "minor")
+	data.WarningAlarms = countValue(alarmCounts, "warning")
+	deviationCounts, _ := instance["deviations"].(map[string]any)
+	data.DeviationCount = countValue(deviationCounts, "count")
+
+	alarms, err := flattenAlarms(alarmRows)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to build response from alarms query", err.Error())
+		return
+	}
+	deviations, err := flattenDeviations(deviationRows, targets)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to build response from deviations query", err.Error())
+		return
+	}
+	var diags diag.Diagnostics
+	data.Alarms, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: alarmAttrTypes}, alarms)
+	resp.Diagnostics.Append(diags...)
+	data.Deviations, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: deviationAttrTypes}, deviations)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Returns the count of the alarms or deviations object returned by the API, 0 if not reported
+func countValue(counts map[string]any, key string) types.Int64 {
+	count, err := tfutils.NumToInt64(counts[key])
+	if err != nil {
+		return types.Int64Value(0)
+	}
+	return types.Int64Value(count)
+}
+
+// Returns the EQL query of the active alarms raised against the plugin instance
+func alarmsQuery(name string) string {
+	return fmt.Sprintf("%s where (group = %s and kind = %s and resource = %s)", alarmsQueryTable,
+		apiclient.QuoteQueryString(gvk_vmwarePluginInstance.Group),
+		apiclient.QuoteQueryString(gvk_vmwarePluginInstance.Kind),
+		apiclient.QuoteQueryString(name))
+}
+
+// Returns the EQL query of the deviations reported against the nodes targeted by the
+// plugin instance, each node matched in the namespace of the target
+func deviationsQuery(targets []map[string]any) string {
+	clauses := make([]string, 0, len(targets))
+	for _, target := range targets {
+		node, _ := target["name"].(string)
+		clause := "spec.nodeEndpoint = " + apiclient.QuoteQueryString(node)
+		if namespace, _ := target["namespace"].(string); namespace != "" {
+			clause = fmt.Sprintf("(metadata.namespace = %s and %s)", apiclient.QuoteQueryString(namespace), clause)
+		}
+		clauses = append(clauses, clause)
+	}
+	return fmt.Sprintf("%s where (%s)", deviationsQueryTable, strings.Join(clauses, " or "))
+}
+
+// Row of the alarms table, holding the fields of an AlarmData
+type alarmRow struct {
+	Name          string `json:"name"`
+	Severity      string `json:"severity"`
+	Type          string `json:"type"`
+	Description   string `json:"description"`
+	ProbableCause string `json:"probableCause"`
+	Acknowledged  bool   `json:"acknowledged"`
+	LastChanged   string `json:"lastChanged"`
+}
+
+// Row of the deviations table, holding a Deviation resource
+type deviationRow struct {
+	Metadata struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"metadata"`
+	Spec struct {
+		NodeEndpoint string `json:"nodeEndpoint"`
+		Path         string `json:"path"`
+	} `json:"spec"`
+}
+
+// Decodes the rows of a query result into values of type T
+func decodeRows[T any](rows []map[string]any) ([]T, error) {
+	encoded, err := json.Marshal(rows)
+	if err != nil {
+		return nil, err
+	}
+	values := []T{}
+	if err := json.Unmarshal(encoded, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// Returns the alarms of the rows of an alarms query, the most severe first
+func flattenAlarms(rows []map[string]any) ([]instanceAlarm, error) {
+	alarmRows, err := decodeRows[alarmRow](rows)
+	if err != nil {
+		return nil, err
+	}
+	alarms := make([]instanceAlarm, 0, len(alarmRows))
+	for _, row := range alarmRows {
+		alarms = append(alarms, instanceAlarm{
+			Name:          row.Name,
+			Severity:      strings.ToLower(row.Severity),
+			Type:          row.Type,
+			Text:          row.Description,
+			ProbableCause: row.ProbableCause,
+			Acknowledged:  row.Acknowledged,
+			LastChanged:   row.LastChanged,
+		})
+	}
+	slices.SortStableFunc(alarms, func(a, b instanceAlarm) int {
+		return slices.Index(alarmSeverities, b.Severity) - slices.Index(alarmSeverities, a.Severity)
+	})
+	return alarms, nil
+}
+
+// Returns the deviations of the rows of a deviations query reported against one of the
+// targets, in the namespace of the target
+func flattenDeviations(rows []map[string]any, targets []map[string]any) ([]instanceDeviation, error) {
+	deviationRows, err := decodeRows[deviationRow](rows)
+	if err != nil {
+		return nil, err
+	}
+	deviations := []instanceDeviation{}
+	for _, row := range deviationRows {
+		if !slices.ContainsFunc(targets, func(target map[string]any) bool {
+			name, _ := target["name"].(string)
+			namespace, _ := target["namespace"].(string)
+			return name == row.Spec.NodeEndpoint && (namespace == "" || namespace == row.Metadata.Namespace)
+		}) {
+			continue
+		}
+		deviations = append(deviations, instanceDeviation{
+			Name:      row.Metadata.Name,
+			Namespace: row.Metadata.Namespace,
+			Node:      row.Spec.NodeEndpoint,
+			Path:      row.Spec.Path,
+		})
+	}
+	return deviations, nil
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *vmwarePluginInstanceAlarmsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = providerClient(req.ProviderData, &resp.Diagnostics, "Data Source")
+}
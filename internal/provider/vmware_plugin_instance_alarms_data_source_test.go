@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/eda/apiclient"
+)
+
+// EQL query results built from the documented schemas of the rows: the AlarmData of the
+// alarms table, and the Deviation resource of the deviations table. They are not captured
+// from a live EDA API.
+const (
+	testAlarmsQueryResult = `{
+  "data": [
+    {"data": {"name": "vc1-unreachable", "namespace": "eda", "group": "vmware.eda.nokia.com", "kind": "VmwarePluginInstance",
+      "resource": "vc1", "severity": "major", "type": "VCenterUnreachable", "description": "vCenter is unreachable",
+      "probableCause": "the vCenter host does not answer", "remedialAction": "check the vCenter host",
+      "acknowledged": false, "lastChanged": "2026-01-02T03:04:05Z"}},
+    {"data": {"name": "vc1-sync", "namespace": "eda", "group": "vmware.eda.nokia.com", "kind": "VmwarePluginInstance",
+      "resource": "vc1", "severity": "critical", "type": "SyncFailed", "description": "synchronization failed",
+      "probableCause": "", "acknowledged": true, "lastChanged": "2026-01-02T03:05:00Z"}}
+  ]
+}`
+	testDeviationsQueryResult = `{
+  "data": [
+    {"data": {"apiVersion": "core.eda.nokia.com/v1", "kind": "Deviation",
+      "metadata": {"name": "leaf1-interface", "namespace": "eda"},
+      "spec": {"nodeEndpoint": "leaf1", "path": ".interface{.name==\"ethernet-1/1\"}", "accepted": false}}},
+    {"data": {"apiVersion": "core.eda.nokia.com/v1", "kind": "Deviation",
+      "metadata": {"name": "leaf1-interface", "namespace": "lab"},
+      "spec": {"nodeEndpoint": "leaf1", "path": ".interface{.name==\"ethernet-1/2\"}", "accepted": false}}},
+    {"data": {"apiVersion": "core.eda.nokia.com/v1", "kind": "Deviation",
+      "metadata": {"name": "spine1-interface", "namespace": "eda"},
+      "spec": {"nodeEndpoint": "spine1", "path": ".interface{.name==\"ethernet-1/1\"}", "accepted": false}}}
+  ]
+}`
+)
+
+// Returns a client of a fake EQL query API, answering queries of the alarms and deviations
+// tables with the given results, and the queries it received
+func newTestQueryClient(t *testing.T, alarms, deviations string) (*apiclient.EdaApiClient, *[]string) {
+	queries := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != apiclient.QUERY_URL {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		query := r.URL.Query().Get(apiclient.KEY_QUERY)
+		queries = append(queries, query)
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasPrefix(query, alarmsQueryTable) {
+			_, _ = w.Write([]byte(alarms))
+		} else {
+			_, _ = w.Write([]byte(deviations))
+		}
+	}))
+	t.Cleanup(server.Close)
+	client, err := apiclient.NewEdaApiClient(context.Background(), &apiclient.Config{
+		BaseURL:     server.URL,
+		AuthMode:    apiclient.AUTH_MODE_TOKEN,
+		AccessToken: "test-token",
+		RestTimeout: 5 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, &queries
+}
+
+func TestAlarmsQuery(t *testing.T) {
+	client, queries := newTestQueryClient(t, testAlarmsQueryResult, testDeviationsQueryResult)
+	rows, err := client.Query(context.Background(), alarmsQuery("vc1"), "eda")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedQuery := `.namespace.alarms.v1.current-alarm where (group = "vmware.eda.nokia.com" and kind = "VmwarePluginInstance" and resource = "vc1")`
+	if len(*queries) != 1 || (*queries)[0] != expectedQuery {
+		t.Errorf("queries = %q, want %q", *queries, expectedQuery)
+	}
+
+	alarms, err := flattenAlarms(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []instanceAlarm{
+		{Name: "vc1-sync", Severity: "critical", Type: "SyncFailed", Text: "synchronization failed",
+			Acknowledged: true, LastChanged: "2026-01-02T03:05:00Z"},
+		{Name: "vc1-unreachable", Severity: "major", Type: "VCenterUnreachable", Text: "vCenter is unreachable",
+			ProbableCause: "the vCenter host does not answer", LastChanged: "2026-01-02T03:04:05Z"},
+	}
+	if !reflect.DeepEqual(alarms, expected) {
+		t.Errorf("alarms = %+v, want %+v", alarms, expected)
+	}
+}
+
+func TestDeviationsQuery(t *testing.T) {
+	client, queries := newTestQueryClient(t, testAlarmsQueryResult, testDeviationsQueryResult)
+	targets := []map[string]any{
+		{"name": "leaf1", "namespace": "eda"},
+		{"name": "leaf2", "namespace": "eda"},
+	}
+	rows, err := client.Query(context.Background(), deviationsQuery(targets), "eda")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedQuery := `.namespace.resources.cr.core_eda_nokia_com.v1.deviation where (` +
+		`(metadata.namespace = "eda" and spec.nodeEndpoint = "leaf1") or (metadata.namespace = "eda" and spec.nodeEndpoint = "leaf2"))`
+	if len(*queries) != 1 || (*queries)[0] != expectedQuery {
+		t.Errorf("queries = %q, want %q", *queries, expectedQuery)
+	}
+
+	// The fake API does not filter, the deviations of other nodes and namespaces are left out
+	deviations, err := flattenDeviations(rows, targets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []instanceDeviation{
+		{Name: "leaf1-interface", Namespace: "eda", Node: "leaf1", Path: `.interface{.name=="ethernet-1/1"}`},
+	}
+	if !reflect.DeepEqual(deviations, expected) {
+		t.Errorf("deviations = %+v, want %+v", deviations, expected)
+	}
+}
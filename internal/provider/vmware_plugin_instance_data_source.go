@@ -5,8 +5,9 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/davecgh/go-spew/spew"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/datasource_vmware_plugin_instance"
 	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/eda/apiclient"
@@ -28,16 +29,29 @@ type vmwarePluginInstanceDataSource struct {
 	client *apiclient.EdaApiClient
 }
 
+// vmwarePluginInstanceDataSourceModel extends the generated model with the
+// namespace the data is read from, which is sent as part of the API path
+type vmwarePluginInstanceDataSourceModel struct {
+	datasource_vmware_plugin_instance.VmwarePluginInstanceModel
+	Namespace types.String `tfsdk:"namespace"`
+}
+
 func (d *vmwarePluginInstanceDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_vmware_plugin_instance"
 }
 
 func (d *vmwarePluginInstanceDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = datasource_vmware_plugin_instance.VmwarePluginInstanceDataSourceSchema(ctx)
+	resp.Schema.Attributes["namespace"] = schema.StringAttribute{
+		Optional:            true,
+		Description:         "namespace of the VmwarePluginInstance",
+		MarkdownDescription: "namespace of the VmwarePluginInstance",
+	}
 }
 
 func (d *vmwarePluginInstanceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data datasource_vmware_plugin_instance.VmwarePluginInstanceModel
+	ctx = tfutils.MaskSensitiveFields(ctx, d.client.SensitiveKeys())
+	var data vmwarePluginInstanceDataSourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -47,7 +61,7 @@ func (d *vmwarePluginInstanceDataSource) Read(ctx context.Context, req datasourc
 	}
 
 	// Extract query params from Terraform model
-	queryParams, err := tfutils.ModelToStringMap(ctx, &data)
+	queryParams, err := tfutils.ModelToStringMap(ctx, &data.VmwarePluginInstanceModel)
 	if err != nil {
 		resp.Diagnostics.AddError("Error extracting query params", err.Error())
 		return
@@ -56,29 +70,30 @@ func (d *vmwarePluginInstanceDataSource) Read(ctx context.Context, req datasourc
 	// Read API call logic
 	tflog.Info(ctx, "Read()::API request", map[string]any{
 		"path":  read_ds_vmwarePluginInstance,
-		"data":  spew.Sdump(data),
+		"data":  tfutils.RedactedDump(ctx, &data),
 		"query": queryParams,
 	})
 
 	t0 := time.Now()
 	result := map[string]any{}
-	err = d.client.GetByQuery(ctx, read_ds_vmwarePluginInstance, map[string]string{
+	readPath, pathParams := apiclient.NamespacedPath(read_ds_vmwarePluginInstance, map[string]string{
 		"name": tfutils.StringValue(data.Name),
-	}, queryParams, &result)
+	}, tfutils.StringValue(data.Namespace))
+	err = d.client.GetByQuery(ctx, readPath, pathParams, queryParams, &result)
 
 	tflog.Info(ctx, "Read()::API returned", map[string]any{
-		"path":      read_ds_vmwarePluginInstance,
-		"result":    spew.Sdump(result),
+		"path":      readPath,
+		"result":    tfutils.RedactedDump(ctx, result),
 		"timeTaken": time.Since(t0).String(),
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading resource", err.Error())
+		addApiErrorDiagnostics(&resp.Diagnostics, "Error reading resource", err)
 		return
 	}
 
 	// Convert API response to Terraform model
-	err = tfutils.AnyMapToModel(ctx, result, &data)
+	err = tfutils.AnyMapToModel(ctx, result, &data.VmwarePluginInstanceModel)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to build response from API result", err.Error())
 		return
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/eda/apiclient"
+	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/tfutils"
+)
+
+const read_ds_vmwarePluginInstanceDeletedList = "/apps/vmware.eda.nokia.com/v1/vmwareplugininstances/_deleted"
+
+var (
+	_ datasource.DataSource              = (*vmwarePluginInstanceDeletedListDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*vmwarePluginInstanceDeletedListDataSource)(nil)
+)
+
+// Attribute types of a VmwarePluginInstance_DeletedResourceEntry
+var deletedEntryAttrTypes = map[string]attr.Type{
+	"commit_time":    types.StringType,
+	"hash":           types.StringType,
+	"name":           types.StringType,
+	"namespace":      types.StringType,
+	"transaction_id": types.Int64Type,
+}
+
+func NewVmwarePluginInstanceDeletedListDataSource() datasource.DataSource {
+	return &vmwarePluginInstanceDeletedListDataSource{}
+}
+
+type vmwarePluginInstanceDeletedListDataSource struct {
+	client *apiclient.EdaApiClient
+}
+
+type vmwarePluginInstanceDeletedListModel struct {
+	Name      types.String `tfsdk:"name"`
+	Namespace types.String `tfsdk:"namespace"`
+	Items     types.List   `tfsdk:"items"`
+}
+
+func (d *vmwarePluginInstanceDeletedListDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vmware_plugin_instance_deleted_list"
+}
+
+func (d *vmwarePluginInstanceDeletedListDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "VmwarePluginInstances that have been deleted",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "only return the deletions of VmwarePluginInstances with this name",
+			},
+			"namespace": schema.StringAttribute{
+				Optional:    true,
+				Description: "only return the deletions of VmwarePluginInstances in this namespace",
+			},
+			"items": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "deleted VmwarePluginInstances",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"commit_time":    schema.StringAttribute{Computed: true},
+						"hash":           schema.StringAttribute{Computed: true},
+						"name":           schema.StringAttribute{Computed: true},
+						"namespace":      schema.StringAttribute{Computed: true},
+						"transaction_id": schema.Int64Attribute{Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *vmwarePluginInstanceDeletedListDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = tfutils.MaskSensitiveFields(ctx, d.client.SensitiveKeys())
+	var data vmwarePluginInstanceDeletedListModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := tfutils.StringValue(data.Name)
+	namespace := tfutils.StringValue(data.Namespace)
+
+	// Read API call logic
+	tflog.Info(ctx, "Read()::API request", map[string]any{
+		"path": read_ds_vmwarePluginInstanceDeletedList,
+		"data": tfutils.RedactedDump(ctx, &data),
+	})
+
+	t0 := time.Now()
+	entries := []any{}
+	readPath, pathParams := apiclient.NamespacedPath(read_ds_vmwarePluginInstanceDeletedList, nil, namespace)
+	err := d.client.Get(ctx, readPath, pathParams, &entries)
+
+	tflog.Info(ctx, "Read()::API returned", map[string]any{
+		"path":      readPath,
+		"result":    tfutils.RedactedDump(ctx, entries),
+		"timeTaken": time.Since(t0).String(),
+	})
+
+	if err != nil {
+		addApiErrorDiagnostics(&resp.Diagnostics, "Error reading deleted resources", err)
+		return
+	}
+
+	filtered := []any{}
+	for _, e := range entries {
+		entry, ok := e.(map[string]any)
+		if !ok {
+			continue
+		}
+		if (name != "" && entry["name"] != name) || (namespace != "" && entry["namespace"] != namespace) {
+			continue
+		}
+		filtered = append(filtered, entry)
+	}
+
+	// Convert API response to Terraform model
+	data.Items, err = tfutils.AnyListToList(ctx, types.ObjectType{AttrTypes: deletedEntryAttrTypes}, filtered)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to build response from API result", err.Error())
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *vmwarePluginInstanceDeletedListDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = providerClient(req.ProviderData, &resp.Diagnostics, "Data Source")
+}
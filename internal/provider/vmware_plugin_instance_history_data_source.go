@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/eda/apiclient"
+	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/tfutils"
+)
+
+const read_ds_vmwarePluginInstanceHistory = "/apps/vmware.eda.nokia.com/v1/vmwareplugininstances/{name}/_revs"
+
+var (
+	_ datasource.DataSource              = (*vmwarePluginInstanceHistoryDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*vmwarePluginInstanceHistoryDataSource)(nil)
+)
+
+// Attribute types of a ResourceHistoryEntry
+var historyEntryAttrTypes = map[string]attr.Type{
+	"author":         types.StringType,
+	"change_type":    types.StringType,
+	"commit_time":    types.StringType,
+	"hash":           types.StringType,
+	"message":        types.StringType,
+	"transaction_id": types.Int64Type,
+}
+
+func NewVmwarePluginInstanceHistoryDataSource() datasource.DataSource {
+	return &vmwarePluginInstanceHistoryDataSource{}
+}
+
+type vmwarePluginInstanceHistoryDataSource struct {
+	client *apiclient.EdaApiClient
+}
+
+// vmwarePluginInstanceHistoryModel is shared by the history data source and ephemeral resource
+type vmwarePluginInstanceHistoryModel struct {
+	Name      types.String `tfsdk:"name"`
+	Namespace types.String `tfsdk:"namespace"`
+	Limit     types.Int64  `tfsdk:"limit"`
+	Entries   types.List   `tfsdk:"entries"`
+}
+
+func (d *vmwarePluginInstanceHistoryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vmware_plugin_instance_history"
+}
+
+func (d *vmwarePluginInstanceHistoryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = vmwarePluginInstanceHistorySchema()
+}
+
+// Returns the schema of the history data source, from which the schema of the
+// ephemeral resource is derived
+func vmwarePluginInstanceHistorySchema() schema.Schema {
+	return schema.Schema{
+		Description: "Revision history of a VmwarePluginInstance, most recent first",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "name of the VmwarePluginInstance",
+			},
+			"namespace": schema.StringAttribute{
+				Optional:    true,
+				Description: "namespace of the VmwarePluginInstance",
+			},
+			"limit": schema.Int64Attribute{
+				Optional:    true,
+				Description: "maximum number of revisions to return",
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"entries": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "revisions of the VmwarePluginInstance",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"author":         schema.StringAttribute{Computed: true},
+						"change_type":    schema.StringAttribute{Computed: true},
+						"commit_time":    schema.StringAttribute{Computed: true},
+						"hash":           schema.StringAttribute{Computed: true},
+						"message":        schema.StringAttribute{Computed: true},
+						"transaction_id": schema.Int64Attribute{Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *vmwarePluginInstanceHistoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = tfutils.MaskSensitiveFields(ctx, d.client.SensitiveKeys())
+	var data vmwarePluginInstanceHistoryModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(readVmwarePluginInstanceHistory(ctx, d.client, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Reads the revisions of the plugin instance named in data into its entries
+func readVmwarePluginInstanceHistory(ctx context.Context, client *apiclient.EdaApiClient, data *vmwarePluginInstanceHistoryModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	queryParams := map[string]string{}
+	if !data.Limit.IsNull() && !data.Limit.IsUnknown() {
+		queryParams["limit"] = strconv.FormatInt(data.Limit.ValueInt64(), 10)
+	}
+	readPath, pathParams := apiclient.NamespacedPath(read_ds_vmwarePluginInstanceHistory,
+		map[string]string{"name": data.Name.ValueString()}, tfutils.StringValue(data.Namespace))
+
+	// Read API call logic
+	tflog.Info(ctx, "Read()::API request", map[string]any{
+		"path":  readPath,
+		"query": queryParams,
+	})
+
+	t0 := time.Now()
+	entries := []any{}
+	err := client.GetByQuery(ctx, readPath, pathParams, queryParams, &entries)
+
+	tflog.Info(ctx, "Read()::API returned", map[string]any{
+		"path":      readPath,
+		"result":    tfutils.RedactedDump(ctx, entries),
+		"timeTaken": time.Since(t0).String(),
+	})
+
+	if err != nil {
+		addApiErrorDiagnostics(&diags, "Error reading resource history", err)
+		return diags
+	}
+
+	// Convert API response to Terraform model
+	entriesVal, err := tfutils.AnyListToList(ctx, types.ObjectType{AttrTypes: historyEntryAttrTypes}, entries)
+	if err != nil {
+		diags.AddError("Failed to build response from API result", err.Error())
+		return diags
+	}
+	data.Entries = entriesVal
+	return diags
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *vmwarePluginInstanceHistoryDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = providerClient(req.ProviderData, &resp.Diagnostics, "Data Source")
+}
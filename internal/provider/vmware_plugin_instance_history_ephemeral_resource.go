@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/eda/apiclient"
+	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/tfutils"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = (*vmwarePluginInstanceHistoryEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithConfigure = (*vmwarePluginInstanceHistoryEphemeralResource)(nil)
+)
+
+func NewVmwarePluginInstanceHistoryEphemeralResource() ephemeral.EphemeralResource {
+	return &vmwarePluginInstanceHistoryEphemeralResource{}
+}
+
+// vmwarePluginInstanceHistoryEphemeralResource reads the same revision history as the
+// data source, without storing it in the plan or state
+type vmwarePluginInstanceHistoryEphemeralResource struct {
+	client *apiclient.EdaApiClient
+}
+
+func (e *vmwarePluginInstanceHistoryEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vmware_plugin_instance_history"
+}
+
+func (e *vmwarePluginInstanceHistoryEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	var diags diag.Diagnostics
+	resp.Schema, diags = ephemeralSchema(vmwarePluginInstanceHistorySchema())
+	resp.Diagnostics.Append(diags...)
+}
+
+func (e *vmwarePluginInstanceHistoryEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	ctx = tfutils.MaskSensitiveFields(ctx, e.client.SensitiveKeys())
+	var data vmwarePluginInstanceHistoryModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(readVmwarePluginInstanceHistory(ctx, e.client, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// Configure adds the provider configured client to the ephemeral resource.
+func (e *vmwarePluginInstanceHistoryEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	e.client = providerClient(req.ProviderData, &resp.Diagnostics, "Ephemeral Resource")
+}
+
+// Converts the schema of a data source to the schema of an ephemeral resource reading the
+// same data. Only the attribute types used by such data sources are supported.
+func ephemeralSchema(ds dsschema.Schema) (schema.Schema, diag.Diagnostics) {
+	attributes, diags := ephemeralAttributes(ds.Attributes)
+	return schema.Schema{
+		Description:         ds.Description,
+		MarkdownDescription: ds.MarkdownDescription,
+		DeprecationMessage:  ds.DeprecationMessage,
+		Attributes:          attributes,
+	}, diags
+}
+
+func ephemeralAttributes(dsAttributes map[string]dsschema.Attribute) (map[string]schema.Attribute, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	attributes := make(map[string]schema.Attribute, len(dsAttributes))
+	for name, attribute := range dsAttributes {
+		switch a := attribute.(type) {
+		case dsschema.StringAttribute:
+			attributes[name] = schema.StringAttribute{
+				Required: a.Required, Optional: a.Optional, Computed: a.Computed, Sensitive: a.Sensitive,
+				Description: a.Description, MarkdownDescription: a.MarkdownDescription, Validators: a.Validators,
+			}
+		case dsschema.Int64Attribute:
+			attributes[name] = schema.Int64Attribute{
+				Required: a.Required, Optional: a.Optional, Computed: a.Computed, Sensitive: a.Sensitive,
+				Description: a.Description, MarkdownDescription: a.MarkdownDescription, Validators: a.Validators,
+			}
+		case dsschema.BoolAttribute:
+			attributes[name] = schema.BoolAttribute{
+				Required: a.Required, Optional: a.Optional, Computed: a.Computed, Sensitive: a.Sensitive,
+				Description: a.Description, MarkdownDescription: a.MarkdownDescription, Validators: a.Validators,
+			}
+		case dsschema.ListNestedAttribute:
+			nested, d := ephemeralAttributes(a.NestedObject.Attributes)
+			diags.Append(d...)
+			attributes[name] = schema.ListNestedAttribute{
+				Required: a.Required, Optional: a.Optional, Computed: a.Computed, Sensitive: a.Sensitive,
+				Description: a.Description, MarkdownDescription: a.MarkdownDescription, Validators: a.Validators,
+				NestedObject: schema.NestedAttributeObject{Attributes: nested},
+			}
+		default:
+			diags.AddError("Unsupported Schema Attribute",
+				fmt.Sprintf("Attribute %s of type %T can not be converted to an ephemeral resource attribute. "+
+					"Please report this issue to the provider developers.", name, attribute))
+		}
+	}
+	return attributes, diags
+}
@@ -5,8 +5,9 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/davecgh/go-spew/spew"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/datasource_vmware_plugin_instance_list"
 	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/eda/apiclient"
@@ -28,16 +29,29 @@ type vmwarePluginInstanceListDataSource struct {
 	client *apiclient.EdaApiClient
 }
 
+// vmwarePluginInstanceListDataSourceModel extends the generated model with the
+// namespace the data is read from, which is sent as part of the API path
+type vmwarePluginInstanceListDataSourceModel struct {
+	datasource_vmware_plugin_instance_list.VmwarePluginInstanceListModel
+	Namespace types.String `tfsdk:"namespace"`
+}
+
 func (d *vmwarePluginInstanceListDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_vmware_plugin_instance_list"
 }
 
 func (d *vmwarePluginInstanceListDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = datasource_vmware_plugin_instance_list.VmwarePluginInstanceListDataSourceSchema(ctx)
+	resp.Schema.Attributes["namespace"] = schema.StringAttribute{
+		Optional:            true,
+		Description:         "namespace to list the VmwarePluginInstances from",
+		MarkdownDescription: "namespace to list the VmwarePluginInstances from",
+	}
 }
 
 func (d *vmwarePluginInstanceListDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data datasource_vmware_plugin_instance_list.VmwarePluginInstanceListModel
+	ctx = tfutils.MaskSensitiveFields(ctx, d.client.SensitiveKeys())
+	var data vmwarePluginInstanceListDataSourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -47,7 +61,7 @@ func (d *vmwarePluginInstanceListDataSource) Read(ctx context.Context, req datas
 	}
 
 	// Extract query params from Terraform model
-	queryParams, err := tfutils.ModelToStringMap(ctx, &data)
+	queryParams, err := tfutils.ModelToStringMap(ctx, &data.VmwarePluginInstanceListModel)
 	if err != nil {
 		resp.Diagnostics.AddError("Error extracting query params", err.Error())
 		return
@@ -56,27 +70,28 @@ func (d *vmwarePluginInstanceListDataSource) Read(ctx context.Context, req datas
 	// Read API call logic
 	tflog.Info(ctx, "Read()::API request", map[string]any{
 		"path":  read_ds_vmwarePluginInstanceList,
-		"data":  spew.Sdump(data),
+		"data":  tfutils.RedactedDump(ctx, &data),
 		"query": queryParams,
 	})
 
 	t0 := time.Now()
 	result := map[string]any{}
-	err = d.client.GetByQuery(ctx, read_ds_vmwarePluginInstanceList, nil, queryParams, &result)
+	readPath, pathParams := apiclient.NamespacedPath(read_ds_vmwarePluginInstanceList, nil, tfutils.StringValue(data.Namespace))
+	err = d.client.GetByQuery(ctx, readPath, pathParams, queryParams, &result)
 
 	tflog.Info(ctx, "Read()::API returned", map[string]any{
-		"path":      read_ds_vmwarePluginInstanceList,
-		"result":    spew.Sdump(result),
+		"path":      readPath,
+		"result":    tfutils.RedactedDump(ctx, result),
 		"timeTaken": time.Since(t0).String(),
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading resource", err.Error())
+		addApiErrorDiagnostics(&resp.Diagnostics, "Error reading resource", err)
 		return
 	}
 
 	// Convert API response to Terraform model
-	err = tfutils.AnyMapToModel(ctx, result, &data)
+	err = tfutils.AnyMapToModel(ctx, result, &data.VmwarePluginInstanceListModel)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to build response from API result", err.Error())
 		return
@@ -0,0 +1,298 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/eda/apiclient"
+	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/tfutils"
+)
+
+const (
+	list_rs_vmwarePluginInstancePurge   = "/apps/vmware.eda.nokia.com/v1/vmwareplugininstances"
+	delete_rs_vmwarePluginInstancePurge = "/apps/vmware.eda.nokia.com/v1/vmwareplugininstances"
+)
+
+var (
+	_ resource.Resource               = (*vmwarePluginInstancePurgeResource)(nil)
+	_ resource.ResourceWithConfigure  = (*vmwarePluginInstancePurgeResource)(nil)
+	_ resource.ResourceWithModifyPlan = (*vmwarePluginInstancePurgeResource)(nil)
+)
+
+func NewVmwarePluginInstancePurgeResource() resource.Resource {
+	return &vmwarePluginInstancePurgeResource{}
+}
+
+// vmwarePluginInstancePurgeResource deletes all the plugin instances matching a
+// label selector when it is destroyed. Creating it does not change anything.
+type vmwarePluginInstancePurgeResource struct {
+	client *apiclient.EdaApiClient
+}
+
+type vmwarePluginInstancePurgeModel struct {
+	Id            types.String `tfsdk:"id"`
+	LabelSelector types.String `tfsdk:"label_selector"`
+	Namespace     types.String `tfsdk:"namespace"`
+	Names         types.List   `tfsdk:"names"`
+	// Write options of the delete request, updated in place
+	DetailLevel     types.String `tfsdk:"detail_level"`
+	DisableBatching types.Bool   `tfsdk:"disable_batching"`
+}
+
+func (r *vmwarePluginInstancePurgeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vmware_plugin_instance_purge"
+}
+
+func (r *vmwarePluginInstancePurgeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Deletes all the VmwarePluginInstances matching a label selector in a single request when destroyed",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "namespace and label selector of the purge",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"label_selector": schema.StringAttribute{
+				Required:      true,
+				Description:   "label selector of the VmwarePluginInstances to delete, e.g. env=lab",
+				Validators:    []validator.String{stringvalidator.LengthAtLeast(1)},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"namespace": schema.StringAttribute{
+				Optional:      true,
+				Description:   "namespace of the VmwarePluginInstances to delete, all namespaces if not set",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"names": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "VmwarePluginInstances currently matching the label selector, as <namespace>/<name>",
+			},
+		},
+	}
+	addWriteOptionsAttributes(resp.Schema.Attributes)
+}
+
+func (r *vmwarePluginInstancePurgeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = tfutils.MaskSensitiveFields(ctx, r.client.SensitiveKeys())
+	var data vmwarePluginInstancePurgeModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := data.LabelSelector.ValueString()
+	if ns := tfutils.StringValue(data.Namespace); ns != "" {
+		id = ns + "/" + id
+	}
+	data.Id = types.StringValue(id)
+
+	resp.Diagnostics.Append(r.readNames(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save created data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *vmwarePluginInstancePurgeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = tfutils.MaskSensitiveFields(ctx, r.client.SensitiveKeys())
+	var data vmwarePluginInstancePurgeModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.readNames(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update only changes the write options, which are used on destroy
+func (r *vmwarePluginInstancePurgeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = tfutils.MaskSensitiveFields(ctx, r.client.SensitiveKeys())
+	var data vmwarePluginInstancePurgeModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.readNames(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *vmwarePluginInstancePurgeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = tfutils.MaskSensitiveFields(ctx, r.client.SensitiveKeys())
+	var data vmwarePluginInstancePurgeModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	queryParams := map[string]string{"labelSelector": data.LabelSelector.ValueString()}
+
+	// Delete API call logic
+	opts := writeOptions(r.client, data.DetailLevel, data.DisableBatching)
+	tflog.Info(ctx, "Delete()::API request", map[string]any{
+		"path":    delete_rs_vmwarePluginInstancePurge,
+		"query":   queryParams,
+		"options": opts,
+	})
+
+	t0 := time.Now()
+	result := map[string]any{}
+
+	deletePath, pathParams := apiclient.NamespacedPath(delete_rs_vmwarePluginInstancePurge, nil, tfutils.StringValue(data.Namespace))
+	err := r.client.DeleteByQuery(ctx, deletePath, pathParams, queryParams, opts, &result)
+
+	tflog.Info(ctx, "Delete()::API returned", map[string]any{
+		"path":      deletePath,
+		"result":    tfutils.RedactedDump(ctx, result),
+		"timeTaken": time.Since(t0).String(),
+	})
+
+	if err != nil {
+		addApiErrorDiagnostics(&resp.Diagnostics, "Error deleting resources", err)
+		return
+	}
+
+	names := instanceNames(result)
+	if len(names) == 0 {
+		resp.Diagnostics.AddWarning("No VmwarePluginInstance deleted",
+			fmt.Sprintf("No VmwarePluginInstance matched the label selector %q.", data.LabelSelector.ValueString()))
+		return
+	}
+	resp.Diagnostics.AddWarning(fmt.Sprintf("Deleted %d VmwarePluginInstances", len(names)),
+		fmt.Sprintf("Deleted the VmwarePluginInstances matching the label selector %q:\n  %s",
+			data.LabelSelector.ValueString(), strings.Join(names, "\n  ")))
+
+	// All the instances are deleted by the same transaction
+	if opts.DetailLevel == apiclient.DETAIL_LEVEL_DETAILED {
+		addWriteTransactionDiagnostics(ctx, &resp.Diagnostics,
+			fmt.Sprintf("Purged VmwarePluginInstances matching %q", data.LabelSelector.ValueString()),
+			r.deletedEntry(ctx, names[0]))
+	}
+}
+
+// ModifyPlan rejects the write options in transaction mode, as for the plugin instances
+func (r *vmwarePluginInstancePurgeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// There is nothing to plan when the resource is destroyed, and the client is not
+	// configured when the provider configuration is not known yet
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+	if r.client.Config().TransactionMode {
+		resp.Diagnostics.Append(validateTransactionWriteOptions(ctx, req.Config)...)
+	}
+}
+
+// Returns the entry recording the most recent deletion of the plugin instance named
+// <namespace>/<name>, or nil if the API does not record it
+func (r *vmwarePluginInstancePurgeResource) deletedEntry(ctx context.Context, qualifiedName string) map[string]any {
+	namespace, name, found := strings.Cut(qualifiedName, "/")
+	if !found {
+		namespace, name = "", qualifiedName
+	}
+	deletedPath, pathParams := apiclient.NamespacedPath(deleted_rs_vmwarePluginInstance, nil, namespace)
+	entries := []map[string]any{}
+	if err := r.client.Get(ctx, deletedPath, pathParams, &entries); err != nil {
+		tflog.Debug(ctx, "deletedEntry()::Failed to get deleted resources", map[string]any{
+			"path":  deletedPath,
+			"error": err.Error(),
+		})
+		return nil
+	}
+	return lastDeletedEntry(entries, name, namespace)
+}
+
+// Reads the names of the plugin instances currently matching the label selector
+func (r *vmwarePluginInstancePurgeResource) readNames(ctx context.Context, data *vmwarePluginInstancePurgeModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	queryParams := map[string]string{"labelSelector": data.LabelSelector.ValueString()}
+
+	// Read API call logic
+	tflog.Info(ctx, "Read()::API request", map[string]any{
+		"path":  list_rs_vmwarePluginInstancePurge,
+		"query": queryParams,
+	})
+
+	t0 := time.Now()
+	result := map[string]any{}
+
+	readPath, pathParams := apiclient.NamespacedPath(list_rs_vmwarePluginInstancePurge, nil, tfutils.StringValue(data.Namespace))
+	err := r.client.GetByQuery(ctx, readPath, pathParams, queryParams, &result)
+
+	tflog.Info(ctx, "Read()::API returned", map[string]any{
+		"path":      readPath,
+		"result":    tfutils.RedactedDump(ctx, result),
+		"timeTaken": time.Since(t0).String(),
+	})
+
+	if err != nil {
+		addApiErrorDiagnostics(&diags, "Error reading resources", err)
+		return diags
+	}
+
+	data.Names, diags = types.ListValueFrom(ctx, types.StringType, instanceNames(result))
+	return diags
+}
+
+// Returns the sorted <namespace>/<name> of the items of a VmwarePluginInstanceList
+func instanceNames(list map[string]any) []string {
+	names := []string{}
+	items, _ := list["items"].([]any)
+	for _, item := range items {
+		obj, _ := item.(map[string]any)
+		metadata, _ := obj["metadata"].(map[string]any)
+		name, _ := metadata["name"].(string)
+		if name == "" {
+			continue
+		}
+		if namespace, _ := metadata["namespace"].(string); namespace != "" {
+			name = namespace + "/" + name
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *vmwarePluginInstancePurgeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = providerClient(req.ProviderData, &resp.Diagnostics, "Resource")
+}
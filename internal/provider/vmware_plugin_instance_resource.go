@@ -3,12 +3,20 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/eda/apiclient"
 	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/resource_vmware_plugin_instance"
@@ -16,16 +24,30 @@ import (
 )
 
 const (
-	create_rs_vmwarePluginInstance = "/apps/vmware.eda.nokia.com/v1/vmwareplugininstances"
-	read_rs_vmwarePluginInstance   = "/apps/vmware.eda.nokia.com/v1/vmwareplugininstances/{name}"
-	update_rs_vmwarePluginInstance = "/apps/vmware.eda.nokia.com/v1/vmwareplugininstances/{name}"
-	delete_rs_vmwarePluginInstance = "/apps/vmware.eda.nokia.com/v1/vmwareplugininstances/{name}"
+	create_rs_vmwarePluginInstance  = "/apps/vmware.eda.nokia.com/v1/vmwareplugininstances"
+	read_rs_vmwarePluginInstance    = "/apps/vmware.eda.nokia.com/v1/vmwareplugininstances/{name}"
+	update_rs_vmwarePluginInstance  = "/apps/vmware.eda.nokia.com/v1/vmwareplugininstances/{name}"
+	patch_rs_vmwarePluginInstance   = "/apps/vmware.eda.nokia.com/v1/vmwareplugininstances/{name}"
+	delete_rs_vmwarePluginInstance  = "/apps/vmware.eda.nokia.com/v1/vmwareplugininstances/{name}"
+	deleted_rs_vmwarePluginInstance = "/apps/vmware.eda.nokia.com/v1/vmwareplugininstances/_deleted"
+	revs_rs_vmwarePluginInstance    = "/apps/vmware.eda.nokia.com/v1/vmwareplugininstances/{name}/_revs"
 )
 
+// Number of revisions read to find the revisions committed since the last read of a plugin instance
+const conflictRevsLimit = 20
+
+// Type of a VmwarePluginInstance in transactions
+var gvk_vmwarePluginInstance = apiclient.GroupVersionKind{Group: "vmware.eda.nokia.com", Version: "v1", Kind: "VmwarePluginInstance"}
+
+// Top level fields of a VmwarePluginInstance that are owned by the user, and
+// are compared against the prior state to build the JSON patch on update
+var patchableFields_vmwarePluginInstance = []string{"metadata", "spec"}
+
 var (
 	_ resource.Resource                = (*vmwarePluginInstanceResource)(nil)
 	_ resource.ResourceWithConfigure   = (*vmwarePluginInstanceResource)(nil)
 	_ resource.ResourceWithImportState = (*vmwarePluginInstanceResource)(nil)
+	_ resource.ResourceWithModifyPlan  = (*vmwarePluginInstanceResource)(nil)
 )
 
 func NewVmwarePluginInstanceResource() resource.Resource {
@@ -36,16 +58,38 @@ type vmwarePluginInstanceResource struct {
 	client *apiclient.EdaApiClient
 }
 
+// vmwarePluginInstanceModel extends the generated model with the attributes
+// that are handled by the provider itself and are not sent to the API
+type vmwarePluginInstanceModel struct {
+	resource_vmware_plugin_instance.VmwarePluginInstanceModel
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+	DetailLevel     types.String   `tfsdk:"detail_level"`
+	DisableBatching types.Bool     `tfsdk:"disable_batching"`
+	LastCommitHash  types.String   `tfsdk:"last_commit_hash"`
+}
+
 func (r *vmwarePluginInstanceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_vmware_plugin_instance"
 }
 
 func (r *vmwarePluginInstanceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = resource_vmware_plugin_instance.VmwarePluginInstanceResourceSchema(ctx)
+	resp.Schema.Attributes["timeouts"] = timeouts.Attributes(ctx, timeouts.Opts{
+		Create: true,
+		Update: true,
+	})
+	addWriteOptionsAttributes(resp.Schema.Attributes)
+	resp.Schema.Attributes["last_commit_hash"] = schema.StringAttribute{
+		Computed: true,
+		Description: "hash of the last commit of the VmwarePluginInstance seen by Terraform. Updates and deletes fail " +
+			"if the VmwarePluginInstance was changed since then",
+		PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+	}
 }
 
 func (r *vmwarePluginInstanceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data resource_vmware_plugin_instance.VmwarePluginInstanceModel
+	ctx = tfutils.MaskSensitiveFields(ctx, r.client.SensitiveKeys())
+	var data vmwarePluginInstanceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -55,71 +99,103 @@ func (r *vmwarePluginInstanceResource) Create(ctx context.Context, req resource.
 	}
 
 	// Initialize unknown values with null defaults
-	err := tfutils.FillMissingValues(ctx, &data)
+	err := tfutils.FillMissingValues(ctx, &data.VmwarePluginInstanceModel)
 	if err != nil {
 		resp.Diagnostics.AddError("Error filling missing values", err.Error())
 		return
 	}
 
 	// Convert Terraform model to API request body
-	reqBody, err := tfutils.ModelToAnyMap(ctx, &data)
+	reqBody, err := tfutils.ModelToAnyMap(ctx, &data.VmwarePluginInstanceModel)
 	if err != nil {
 		resp.Diagnostics.AddError("Error building request", err.Error())
 		return
 	}
 
 	// Create API call logic
+	opts := writeOptions(r.client, data.DetailLevel, data.DisableBatching)
 	tflog.Info(ctx, "Create()::API request", map[string]any{
-		"path": create_rs_vmwarePluginInstance,
-		"body": spew.Sdump(reqBody),
+		"path":    create_rs_vmwarePluginInstance,
+		"body":    tfutils.RedactedDump(ctx, reqBody),
+		"options": opts,
 	})
 
 	t0 := time.Now()
 	result := map[string]any{}
 
-	err = r.client.Create(ctx, create_rs_vmwarePluginInstance, nil, reqBody, &result)
+	createPath, pathParams := vmwarePluginInstancePath(create_rs_vmwarePluginInstance, &data.VmwarePluginInstanceModel)
+	if r.client.Config().TransactionMode {
+		err = r.transact(ctx, &resp.Diagnostics, apiclient.TransactionCr{Type: apiclient.TransactionType{
+			Create: &apiclient.TransactionValue{Value: withTypeMeta_vmwarePluginInstance(reqBody)},
+		}})
+	} else {
+		err = r.client.Create(ctx, createPath, pathParams, opts, reqBody, &result)
+	}
 
 	tflog.Info(ctx, "Create()::API returned", map[string]any{
-		"path":      create_rs_vmwarePluginInstance,
-		"result":    spew.Sdump(result),
+		"path":      createPath,
+		"result":    tfutils.RedactedDump(ctx, result),
 		"timeTaken": time.Since(t0).String(),
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating resource", err.Error())
+		addApiErrorDiagnostics(&resp.Diagnostics, "Error creating resource", err)
 		return
 	}
 
 	// Read the resource again to populate any values not available in the response from Create()
 	t0 = time.Now()
 
-	err = r.client.Get(ctx, read_rs_vmwarePluginInstance, map[string]string{
-		"name": tfutils.StringValue(data.Metadata.Name),
-	}, &result)
+	readPath, pathParams := vmwarePluginInstancePath(read_rs_vmwarePluginInstance, &data.VmwarePluginInstanceModel)
+	err = r.client.Get(ctx, readPath, pathParams, &result)
 
 	tflog.Info(ctx, "Read()::API returned", map[string]any{
-		"path":      read_rs_vmwarePluginInstance,
-		"result":    spew.Sdump(result),
+		"path":      readPath,
+		"result":    tfutils.RedactedDump(ctx, result),
 		"timeTaken": time.Since(t0).String(),
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading resource", err.Error())
+		addApiErrorDiagnostics(&resp.Diagnostics, "Error reading resource", err)
 		return
 	}
 
+	// Wait for the plugin instance to report a ready state
+	var readyErr error
+	if r.client.Config().WaitForReady {
+		timeout, d := data.Timeouts.Create(ctx, DEF_READY_TIMEOUT)
+		resp.Diagnostics.Append(d...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		result, readyErr = r.waitForReady(ctx, &data.VmwarePluginInstanceModel, timeout, result)
+	}
+
 	// Convert API response to Terraform model
-	err = tfutils.AnyMapToModel(ctx, result, &data)
+	err = tfutils.AnyMapToModel(ctx, result, &data.VmwarePluginInstanceModel)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to build response from API result", err.Error())
 		return
 	}
+	revision := r.lastRevision(ctx, &data.VmwarePluginInstanceModel)
+	data.LastCommitHash = commitHash(revision)
 	// Save created data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	if opts.DetailLevel == apiclient.DETAIL_LEVEL_DETAILED && !r.client.Config().TransactionMode {
+		addWriteTransactionDiagnostics(ctx, &resp.Diagnostics,
+			"Created VmwarePluginInstance "+tfutils.StringValue(data.Metadata.Name), revision)
+	}
+
+	// The instance is kept in the state, and tainted by the error
+	if readyErr != nil {
+		addApiErrorDiagnostics(&resp.Diagnostics, "Error waiting for resource to become ready", readyErr)
+	}
 }
 
 func (r *vmwarePluginInstanceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data resource_vmware_plugin_instance.VmwarePluginInstanceModel
+	ctx = tfutils.MaskSensitiveFields(ctx, r.client.SensitiveKeys())
+	var data vmwarePluginInstanceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -131,115 +207,416 @@ func (r *vmwarePluginInstanceResource) Read(ctx context.Context, req resource.Re
 	// Read API call logic
 	tflog.Info(ctx, "Read()::API request", map[string]any{
 		"path": read_rs_vmwarePluginInstance,
-		"data": spew.Sdump(data),
+		"data": tfutils.RedactedDump(ctx, &data),
 	})
 
 	t0 := time.Now()
 	result := map[string]any{}
 
-	err := r.client.Get(ctx, read_rs_vmwarePluginInstance, map[string]string{
-		"name": tfutils.StringValue(data.Metadata.Name),
-	}, &result)
+	readPath, pathParams := vmwarePluginInstancePath(read_rs_vmwarePluginInstance, &data.VmwarePluginInstanceModel)
+	err := r.client.Get(ctx, readPath, pathParams, &result)
 
 	tflog.Info(ctx, "Read()::API returned", map[string]any{
-		"path":      read_rs_vmwarePluginInstance,
-		"result":    spew.Sdump(result),
+		"path":      readPath,
+		"result":    tfutils.RedactedDump(ctx, result),
 		"timeTaken": time.Since(t0).String(),
 	})
 
+	if apiclient.IsNotFound(err) {
+		// The instance was deleted outside of Terraform, plan to re-create it
+		tflog.Warn(ctx, "Read()::Resource not found, removing it from state", r.deletionInfo(ctx, &data.VmwarePluginInstanceModel))
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading resource", err.Error())
+		addApiErrorDiagnostics(&resp.Diagnostics, "Error reading resource", err)
 		return
 	}
 
 	// Convert API response to Terraform model
-	err = tfutils.AnyMapToModel(ctx, result, &data)
+	err = tfutils.AnyMapToModel(ctx, result, &data.VmwarePluginInstanceModel)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to build response from API result", err.Error())
 		return
 	}
+	data.LastCommitHash = commitHash(r.lastRevision(ctx, &data.VmwarePluginInstanceModel))
+
+	// Report the active alarms and deviations of the refreshed plugin instance as warnings.
+	// Errors are raised when planning no change, a failed refresh would also fail destroy plans.
+	cfg := r.client.Config()
+	addDriftDiagnostics(&resp.Diagnostics, vmwarePluginInstanceName(&data.VmwarePluginInstanceModel), result,
+		cfg.AlarmWarnings, cfg.AlarmErrorSeverity, cfg.DeviationErrors, false)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *vmwarePluginInstanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data resource_vmware_plugin_instance.VmwarePluginInstanceModel
+	ctx = tfutils.MaskSensitiveFields(ctx, r.client.SensitiveKeys())
+	var data, state vmwarePluginInstanceModel
 
-	// Read Terraform plan data into the model
+	// Read Terraform plan and prior state data into the models
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	err := tfutils.FillMissingValues(ctx, &data)
+	// Values unknown in the plan are left to the server, and must not be patched
+	unknownPaths, err := tfutils.UnknownPaths(ctx, &data.VmwarePluginInstanceModel)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading plan", err.Error())
+		return
+	}
+
+	err = tfutils.FillMissingValues(ctx, &data.VmwarePluginInstanceModel)
 	if err != nil {
 		resp.Diagnostics.AddError("Error filling missing values", err.Error())
 		return
 	}
 
-	reqBody, err := tfutils.ModelToAnyMap(ctx, &data)
+	reqBody, err := tfutils.ModelToAnyMap(ctx, &data.VmwarePluginInstanceModel)
 	if err != nil {
 		resp.Diagnostics.AddError("Error building request", err.Error())
 		return
 	}
 
-	// Update API call logic
-	tflog.Info(ctx, "Update()::API request", map[string]any{
-		"path": update_rs_vmwarePluginInstance,
-		"body": spew.Sdump(reqBody),
-	})
-
-	t0 := time.Now()
-	result := map[string]any{}
+	var patch []apiclient.PatchOp
+	if r.client.Config().UpdateMethod != apiclient.UPDATE_METHOD_PUT {
+		patch, err = updatePatch(ctx, &state.VmwarePluginInstanceModel, reqBody, unknownPaths)
+		if err != nil {
+			resp.Diagnostics.AddError("Error building request", err.Error())
+			return
+		}
+	}
 
-	err = r.client.Update(ctx, update_rs_vmwarePluginInstance, map[string]string{
-		"name": tfutils.StringValue(data.Metadata.Name),
-	}, reqBody, &result)
+	// Fail if the plugin instance was changed since it was last read. The check is made
+	// right before the write, to leave as little time as possible for other changes.
+	resp.Diagnostics.Append(r.checkConflict(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	tflog.Info(ctx, "Update()::API returned", map[string]any{
-		"path":      update_rs_vmwarePluginInstance,
-		"result":    spew.Sdump(result),
-		"timeTaken": time.Since(t0).String(),
-	})
+	result := map[string]any{}
+	opts := writeOptions(r.client, data.DetailLevel, data.DisableBatching)
+	if r.client.Config().UpdateMethod == apiclient.UPDATE_METHOD_PUT {
+		err = r.replace(ctx, &resp.Diagnostics, &data.VmwarePluginInstanceModel, opts, reqBody, &result)
+	} else {
+		err = r.patch(ctx, &resp.Diagnostics, &data.VmwarePluginInstanceModel, opts, patch, &result)
+	}
 
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating resource", err.Error())
+		addApiErrorDiagnostics(&resp.Diagnostics, "Error updating resource", err)
 		return
 	}
 
 	// Read the resource again to populate any values not available in the response from Update()
-	t0 = time.Now()
+	t0 := time.Now()
 
-	err = r.client.Get(ctx, read_rs_vmwarePluginInstance, map[string]string{
-		"name": tfutils.StringValue(data.Metadata.Name),
-	}, &result)
+	readPath, pathParams := vmwarePluginInstancePath(read_rs_vmwarePluginInstance, &data.VmwarePluginInstanceModel)
+	err = r.client.Get(ctx, readPath, pathParams, &result)
 
 	tflog.Info(ctx, "Read()::API returned", map[string]any{
-		"path":      read_rs_vmwarePluginInstance,
-		"result":    spew.Sdump(result),
+		"path":      readPath,
+		"result":    tfutils.RedactedDump(ctx, result),
 		"timeTaken": time.Since(t0).String(),
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading resource", err.Error())
+		addApiErrorDiagnostics(&resp.Diagnostics, "Error reading resource", err)
 		return
 	}
 
+	// Wait for the plugin instance to report a ready state
+	var readyErr error
+	if r.client.Config().WaitForReady {
+		timeout, d := data.Timeouts.Update(ctx, DEF_READY_TIMEOUT)
+		resp.Diagnostics.Append(d...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		result, readyErr = r.waitForReady(ctx, &data.VmwarePluginInstanceModel, timeout, result)
+	}
+
 	// Convert API response to Terraform model
-	err = tfutils.AnyMapToModel(ctx, result, &data)
+	err = tfutils.AnyMapToModel(ctx, result, &data.VmwarePluginInstanceModel)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to build response from API result", err.Error())
 		return
 	}
+	revision := r.lastRevision(ctx, &data.VmwarePluginInstanceModel)
+	// The hash is only planned when the update commits no change, keep it if the
+	// revision can not be read
+	if hash := commitHash(revision); !hash.IsNull() || data.LastCommitHash.IsUnknown() {
+		data.LastCommitHash = hash
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	if opts.DetailLevel == apiclient.DETAIL_LEVEL_DETAILED && !r.client.Config().TransactionMode {
+		addWriteTransactionDiagnostics(ctx, &resp.Diagnostics,
+			"Updated VmwarePluginInstance "+tfutils.StringValue(data.Metadata.Name), revision)
+	}
+
+	// The state records the update that was applied, and the error fails the apply
+	if readyErr != nil {
+		addApiErrorDiagnostics(&resp.Diagnostics, "Error waiting for resource to become ready", readyErr)
+	}
+}
+
+// ModifyPlan plans the last commit hash, rejects write options in transaction mode and
+// reports the alarms and deviations of the plugin instance. When
+// plan validation is enabled, it validates the planned plugin instance against EDA with a
+// dry run transaction, so that invalid resources fail at plan time.
+func (r *vmwarePluginInstanceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// There is nothing to plan when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	resp.Diagnostics.Append(r.planLastCommitHash(ctx, req, resp)...)
+	// The client is not configured when the provider configuration is not known yet
+	if r.client == nil {
+		return
+	}
+	if r.client.Config().TransactionMode {
+		resp.Diagnostics.Append(validateTransactionWriteOptions(ctx, req.Config)...)
+	}
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(r.planDriftDiagnostics(ctx, req)...)
+	}
+	if !r.client.Config().PlanValidation || resp.Diagnostics.HasError() {
+		return
+	}
+	// Unchanged resources were validated when they were planned
+	if !req.State.Raw.IsNull() && req.Plan.Raw.Equal(req.State.Raw) {
+		return
+	}
+	ctx = tfutils.MaskSensitiveFields(ctx, r.client.SensitiveKeys())
+	var data vmwarePluginInstanceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Values known only at apply time can not be validated
+	unknownPaths, err := tfutils.UnknownPaths(ctx, &data.VmwarePluginInstanceModel)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading plan", err.Error())
+		return
+	}
+	for _, p := range unknownPaths {
+		if strings.HasPrefix(p, "/metadata/") || strings.HasPrefix(p, "/spec/") || p == "/metadata" || p == "/spec" {
+			tflog.Info(ctx, "ModifyPlan()::Skipping validation of a plan with unknown values", map[string]any{"path": p})
+			return
+		}
+	}
+
+	err = tfutils.FillMissingValues(ctx, &data.VmwarePluginInstanceModel)
+	if err != nil {
+		resp.Diagnostics.AddError("Error filling missing values", err.Error())
+		return
+	}
+	reqBody, err := tfutils.ModelToAnyMap(ctx, &data.VmwarePluginInstanceModel)
+	if err != nil {
+		resp.Diagnostics.AddError("Error building request", err.Error())
+		return
+	}
+	value := &apiclient.TransactionValue{Value: withTypeMeta_vmwarePluginInstance(filterFields(reqBody, patchableFields_vmwarePluginInstance))}
+	cr := apiclient.TransactionCr{Type: apiclient.TransactionType{Replace: value}}
+	if req.State.Raw.IsNull() {
+		cr = apiclient.TransactionCr{Type: apiclient.TransactionType{Create: value}}
+	}
+
+	// Validate API call logic
+	tflog.Info(ctx, "ModifyPlan()::API request", map[string]any{
+		"path": apiclient.TRANSACTION_URL,
+		"body": tfutils.RedactedDump(ctx, value.Value),
+	})
+
+	// Bound the dry run like committed transactions, so that a transaction stuck on the
+	// server does not hang the plan
+	txCtx, cancel := context.WithTimeout(ctx, apiclient.TRANSACTION_TIMEOUT)
+	defer cancel()
+	t0 := time.Now()
+	result, err := r.client.RunTransaction(txCtx, &apiclient.Transaction{
+		Crs:         []apiclient.TransactionCr{cr},
+		Description: "terraform-provider-vmware: plan validation",
+		DryRun:      true,
+	})
+
+	tflog.Info(ctx, "ModifyPlan()::API returned", map[string]any{
+		"path":      apiclient.TRANSACTION_URL,
+		"success":   err == nil,
+		"timeTaken": time.Since(t0).String(),
+	})
+
+	if err != nil {
+		addValidationDiagnostics(&resp.Diagnostics, "Invalid VmwarePluginInstance", err)
+		return
+	}
+	tflog.Info(ctx, "ModifyPlan()::Dry run succeeded", map[string]any{
+		"transactionId": result.Id,
+		"item":          result.Item().String(),
+	})
+}
+
+// Replaces the whole resource with the planned values using a PUT request
+func (r *vmwarePluginInstanceResource) replace(ctx context.Context, diags *diag.Diagnostics,
+	data *resource_vmware_plugin_instance.VmwarePluginInstanceModel, opts apiclient.WriteOptions, reqBody map[string]any, result *map[string]any) error {
+	// Update API call logic
+	tflog.Info(ctx, "Update()::API request", map[string]any{
+		"path":    update_rs_vmwarePluginInstance,
+		"body":    tfutils.RedactedDump(ctx, reqBody),
+		"options": opts,
+	})
+
+	t0 := time.Now()
+
+	updatePath, pathParams := vmwarePluginInstancePath(update_rs_vmwarePluginInstance, data)
+	var err error
+	if r.client.Config().TransactionMode {
+		err = r.transact(ctx, diags, apiclient.TransactionCr{Type: apiclient.TransactionType{
+			Replace: &apiclient.TransactionValue{Value: withTypeMeta_vmwarePluginInstance(reqBody)},
+		}})
+	} else {
+		err = r.client.Update(ctx, updatePath, pathParams, opts, reqBody, result)
+	}
+
+	tflog.Info(ctx, "Update()::API returned", map[string]any{
+		"path":      updatePath,
+		"result":    tfutils.RedactedDump(ctx, result),
+		"timeTaken": time.Since(t0).String(),
+	})
+	return err
+}
+
+// Plans last_commit_hash as unknown when the update commits a change to the plugin instance.
+// The hash is otherwise kept from the state, as the update only changes provider settings.
+func (r *vmwarePluginInstanceResource) planLastCommitHash(ctx context.Context,
+	req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if req.State.Raw.IsNull() || req.Plan.Raw.Equal(req.State.Raw) {
+		return diags
+	}
+	var plan, state vmwarePluginInstanceModel
+	diags.Append(req.Plan.Get(ctx, &plan)...)
+	diags.Append(req.State.Get(ctx, &state)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Replacing the plugin instance always commits, as does any update without a client
+	commits := true
+	if r.client != nil && r.client.Config().UpdateMethod != apiclient.UPDATE_METHOD_PUT {
+		unknownPaths, err := tfutils.UnknownPaths(ctx, &plan.VmwarePluginInstanceModel)
+		if err != nil {
+			diags.AddError("Error reading plan", err.Error())
+			return diags
+		}
+		if err := tfutils.FillMissingValues(ctx, &plan.VmwarePluginInstanceModel); err != nil {
+			diags.AddError("Error filling missing values", err.Error())
+			return diags
+		}
+		reqBody, err := tfutils.ModelToAnyMap(ctx, &plan.VmwarePluginInstanceModel)
+		if err != nil {
+			diags.AddError("Error building request", err.Error())
+			return diags
+		}
+		patch, err := updatePatch(ctx, &state.VmwarePluginInstanceModel, reqBody, unknownPaths)
+		if err != nil {
+			diags.AddError("Error building request", err.Error())
+			return diags
+		}
+		commits = len(patch) > 0
+	}
+	if commits {
+		diags.Append(resp.Plan.SetAttribute(ctx, path.Root("last_commit_hash"), types.StringUnknown())...)
+	}
+	return diags
+}
+
+// Returns the errors for the alarms and deviations of the plugin instance, as of its last
+// refresh, when the plan leaves the plugin instance unchanged. Plans changing it may clear
+// them. The warnings are left to the refresh, which reports them once per plan.
+func (r *vmwarePluginInstanceResource) planDriftDiagnostics(ctx context.Context, req resource.ModifyPlanRequest) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !req.Plan.Raw.Equal(req.State.Raw) {
+		return diags
+	}
+	var state vmwarePluginInstanceModel
+	diags.Append(req.State.Get(ctx, &state)...)
+	if diags.HasError() {
+		return diags
+	}
+	stateBody, err := tfutils.ModelToAnyMap(ctx, &state.VmwarePluginInstanceModel)
+	if err != nil {
+		diags.AddError("Error reading state", err.Error())
+		return diags
+	}
+	cfg := r.client.Config()
+	addDriftDiagnostics(&diags, vmwarePluginInstanceName(&state.VmwarePluginInstanceModel), stateBody,
+		false, cfg.AlarmErrorSeverity, cfg.DeviationErrors, true)
+	return diags
+}
+
+// Returns the JSON patch of the patchable fields of the plugin instance, from the state to
+// the request body built from the plan. Values unknown in the plan are left to the server,
+// and are not patched.
+func updatePatch(ctx context.Context, state *resource_vmware_plugin_instance.VmwarePluginInstanceModel,
+	reqBody map[string]any, unknownPaths []string) ([]apiclient.PatchOp, error) {
+	stateBody, err := tfutils.ModelToAnyMap(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	return apiclient.CreatePatch(
+		filterFields(stateBody, patchableFields_vmwarePluginInstance),
+		filterFields(reqBody, patchableFields_vmwarePluginInstance),
+		unknownPaths...), nil
+}
+
+// Sends only the changed fields of the resource using a JSON patch request
+func (r *vmwarePluginInstanceResource) patch(ctx context.Context, diags *diag.Diagnostics,
+	data *resource_vmware_plugin_instance.VmwarePluginInstanceModel, opts apiclient.WriteOptions, patch []apiclient.PatchOp, result *map[string]any) error {
+	// Patch API call logic
+	tflog.Info(ctx, "Patch()::API request", map[string]any{
+		"path":    patch_rs_vmwarePluginInstance,
+		"patch":   spew.Sdump(apiclient.RedactPatch(ctx, patch)),
+		"options": opts,
+	})
+
+	if len(patch) == 0 {
+		tflog.Info(ctx, "Patch()::Nothing to patch", map[string]any{"path": patch_rs_vmwarePluginInstance})
+		return nil
+	}
+
+	t0 := time.Now()
+
+	patchPath, pathParams := vmwarePluginInstancePath(patch_rs_vmwarePluginInstance, data)
+	var err error
+	if r.client.Config().TransactionMode {
+		err = r.transact(ctx, diags, apiclient.TransactionCr{Type: apiclient.TransactionType{
+			Patch: &apiclient.TransactionPatch{PatchOps: patch, Target: vmwarePluginInstanceTarget(data)},
+		}})
+	} else {
+		err = r.client.Patch(ctx, patchPath, pathParams, opts, patch, result)
+	}
+
+	tflog.Info(ctx, "Patch()::API returned", map[string]any{
+		"path":      patchPath,
+		"result":    tfutils.RedactedDump(ctx, result),
+		"timeTaken": time.Since(t0).String(),
+	})
+	return err
 }
 
 func (r *vmwarePluginInstanceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data resource_vmware_plugin_instance.VmwarePluginInstanceModel
+	ctx = tfutils.MaskSensitiveFields(ctx, r.client.SensitiveKeys())
+	var data vmwarePluginInstanceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -248,29 +625,51 @@ func (r *vmwarePluginInstanceResource) Delete(ctx context.Context, req resource.
 		return
 	}
 
+	// Fail if the plugin instance was changed since it was last read
+	resp.Diagnostics.Append(r.checkConflict(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Delete API call logic
+	opts := writeOptions(r.client, data.DetailLevel, data.DisableBatching)
 	tflog.Info(ctx, "Delete()::API request", map[string]any{
-		"path": delete_rs_vmwarePluginInstance,
-		"data": spew.Sdump(data),
+		"path":    delete_rs_vmwarePluginInstance,
+		"data":    tfutils.RedactedDump(ctx, &data),
+		"options": opts,
 	})
 
 	t0 := time.Now()
 	result := map[string]any{}
 
-	err := r.client.Delete(ctx, delete_rs_vmwarePluginInstance, map[string]string{
-		"name": tfutils.StringValue(data.Metadata.Name),
-	}, &result)
+	deletePath, pathParams := vmwarePluginInstancePath(delete_rs_vmwarePluginInstance, &data.VmwarePluginInstanceModel)
+	var err error
+	if r.client.Config().TransactionMode {
+		target := vmwarePluginInstanceTarget(&data.VmwarePluginInstanceModel)
+		err = r.transact(ctx, &resp.Diagnostics, apiclient.TransactionCr{Type: apiclient.TransactionType{Delete: &target}})
+	} else {
+		err = r.client.Delete(ctx, deletePath, pathParams, opts, &result)
+	}
 
 	tflog.Info(ctx, "Delete()::API returned", map[string]any{
-		"path":      delete_rs_vmwarePluginInstance,
-		"result":    spew.Sdump(result),
+		"path":      deletePath,
+		"result":    tfutils.RedactedDump(ctx, result),
 		"timeTaken": time.Since(t0).String(),
 	})
 
+	if apiclient.IsNotFound(err) {
+		tflog.Warn(ctx, "Delete()::Resource already deleted", map[string]any{"path": deletePath})
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Error deleting resource", err.Error())
+		addApiErrorDiagnostics(&resp.Diagnostics, "Error deleting resource", err)
 		return
 	}
+
+	if opts.DetailLevel == apiclient.DETAIL_LEVEL_DETAILED && !r.client.Config().TransactionMode {
+		addWriteTransactionDiagnostics(ctx, &resp.Diagnostics,
+			"Deleted VmwarePluginInstance "+tfutils.StringValue(data.Metadata.Name), r.deletedEntry(ctx, &data.VmwarePluginInstanceModel))
+	}
 }
 
 // Configure adds the provider configured client to the resource.
@@ -296,9 +695,232 @@ func (r *vmwarePluginInstanceResource) Configure(_ context.Context, req resource
 // ImportState implements resource.ResourceWithImportState.
 func (r *vmwarePluginInstanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	parts := strings.Split(req.ID, "/")
-	if len(parts) < 1 {
-		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Expected format: id = <name>, got: id = %s", req.ID))
+	if len(parts) > 2 || slices.Contains(parts, "") {
+		resp.Diagnostics.AddError("Invalid ID",
+			fmt.Sprintf("Expected format: id = <name> or id = <namespace>/<name>, got: id = %s", req.ID))
 		return
 	}
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("metadata").AtName("name"), parts[0])...)
+	if len(parts) == 2 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("metadata").AtName("namespace"), parts[0])...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("metadata").AtName("name"), parts[len(parts)-1])...)
+}
+
+// Polls the plugin instance until it reports a ready state, starting from the given result
+func (r *vmwarePluginInstanceResource) waitForReady(ctx context.Context, data *resource_vmware_plugin_instance.VmwarePluginInstanceModel,
+	timeout time.Duration, result map[string]any) (map[string]any, error) {
+	cfg := r.client.Config()
+	cond := readyCondition{field: cfg.ReadyStatusField, readyValues: cfg.ReadyStatusValues, failedValues: cfg.FailedStatusValues}
+	readPath, pathParams := vmwarePluginInstancePath(read_rs_vmwarePluginInstance, data)
+	return waitForReady(ctx, func(ctx context.Context, latest *map[string]any) error {
+		return r.client.Get(ctx, readPath, pathParams, latest)
+	}, readPath, cond, timeout, cfg.ReadyPollInterval, result)
+}
+
+// Returns the log fields describing the plugin instance and, if the API still records
+// its deletion, the commit and transaction that deleted it
+func (r *vmwarePluginInstanceResource) deletionInfo(ctx context.Context,
+	data *resource_vmware_plugin_instance.VmwarePluginInstanceModel) map[string]any {
+	info := map[string]any{
+		"name":      tfutils.StringValue(data.Metadata.Name),
+		"namespace": tfutils.StringValue(data.Metadata.Namespace),
+	}
+	if entry := r.deletedEntry(ctx, data); entry != nil {
+		info["deletedAt"] = entry["commitTime"]
+		info["deletedInTransaction"] = entry["transactionId"]
+		info["deletedInCommit"] = entry["hash"]
+	}
+	return info
+}
+
+// Returns the entry recording the most recent deletion of the plugin instance, or nil
+// if the API does not record it
+func (r *vmwarePluginInstanceResource) deletedEntry(ctx context.Context,
+	data *resource_vmware_plugin_instance.VmwarePluginInstanceModel) map[string]any {
+	deletedPath, pathParams := vmwarePluginInstancePath(deleted_rs_vmwarePluginInstance, data)
+	entries := []map[string]any{}
+	if err := r.client.Get(ctx, deletedPath, pathParams, &entries); err != nil {
+		tflog.Debug(ctx, "deletedEntry()::Failed to get deleted resources", map[string]any{
+			"path":  deletedPath,
+			"error": err.Error(),
+		})
+		return nil
+	}
+	return lastDeletedEntry(entries, tfutils.StringValue(data.Metadata.Name), tfutils.StringValue(data.Metadata.Namespace))
+}
+
+// Returns the most recent of the deleted resource entries matching name and, if set,
+// namespace, or nil if none matches
+func lastDeletedEntry(entries []map[string]any, name, namespace string) map[string]any {
+	// Entries are in commit order, so the last match is the most recent deletion
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if entry["name"] == name && (namespace == "" || entry["namespace"] == namespace) {
+			return entry
+		}
+	}
+	return nil
+}
+
+// Submits a write to the transaction batch of the client and waits for the transaction to
+// complete. The transaction and the result of each of its items are reported as a warning.
+func (r *vmwarePluginInstanceResource) transact(ctx context.Context, diags *diag.Diagnostics, cr apiclient.TransactionCr) error {
+	t0 := time.Now()
+	result, err := r.client.Transact(ctx, cr)
+	if err != nil {
+		return err
+	}
+
+	items := make([]string, len(result.Items))
+	for i, item := range result.Items {
+		items[i] = item.String()
+	}
+	tflog.Info(ctx, "Transact()::API returned", map[string]any{
+		"transactionId": result.Id,
+		"dryRun":        result.DryRun,
+		"item":          result.Item().String(),
+		"items":         items,
+		"timeTaken":     time.Since(t0).String(),
+	})
+
+	if result.DryRun {
+		return &dryRunError{result: result}
+	}
+	item := result.Item()
+	diags.AddWarning(fmt.Sprintf("Committed %s of VmwarePluginInstance %s in transaction %d", item.Operation, item.Name, result.Id),
+		fmt.Sprintf("Transaction %d committed %d change(s):\n  - %s", result.Id, len(items), strings.Join(items, "\n  - ")))
+	return nil
+}
+
+// Returns the revisions of the plugin instance, most recent first
+func (r *vmwarePluginInstanceResource) revisions(ctx context.Context,
+	data *resource_vmware_plugin_instance.VmwarePluginInstanceModel, limit int) ([]map[string]any, error) {
+	revsPath, pathParams := vmwarePluginInstancePath(revs_rs_vmwarePluginInstance, data)
+	queryParams := map[string]string{"limit": strconv.Itoa(limit)}
+	entries := []map[string]any{}
+	err := r.client.GetByQuery(ctx, revsPath, pathParams, queryParams, &entries)
+
+	tflog.Debug(ctx, "revisions()::API returned", map[string]any{
+		"path":    revsPath,
+		"entries": len(entries),
+	})
+	return entries, err
+}
+
+// Returns the last revision of the plugin instance, or nil if it can not be read
+func (r *vmwarePluginInstanceResource) lastRevision(ctx context.Context,
+	data *resource_vmware_plugin_instance.VmwarePluginInstanceModel) map[string]any {
+	entries, err := r.revisions(ctx, data, 1)
+	if err != nil {
+		tflog.Warn(ctx, "lastRevision()::Failed to read the last revision", map[string]any{"error": err.Error()})
+		return nil
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	return entries[0]
+}
+
+// Returns the commit hash of a revision, or null if the revision is not known
+func commitHash(revision map[string]any) types.String {
+	hash, ok := revision["hash"].(string)
+	if !ok {
+		return types.StringNull()
+	}
+	return types.StringValue(hash)
+}
+
+// Returns an error diagnostic listing the revisions committed since the last commit seen by
+// Terraform, if any. The check is skipped if the last commit is not known.
+//
+// The check is best effort: EDA writes can not be made conditional on the last commit, so a
+// change committed between the check and the write is not detected. Callers run it right
+// before the write to keep that window short.
+func (r *vmwarePluginInstanceResource) checkConflict(ctx context.Context, state *vmwarePluginInstanceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	known := tfutils.StringValue(state.LastCommitHash)
+	if known == "" {
+		return diags
+	}
+
+	entries, err := r.revisions(ctx, &state.VmwarePluginInstanceModel, conflictRevsLimit)
+	if apiclient.IsNotFound(err) {
+		return diags
+	}
+	if err != nil {
+		addApiErrorDiagnostics(&diags, "Error reading resource history", err)
+		return diags
+	}
+	if len(entries) == 0 || entries[0]["hash"] == known {
+		return diags
+	}
+
+	sb := strings.Builder{}
+	for _, entry := range entries {
+		if entry["hash"] == known {
+			break
+		}
+		sb.WriteString(fmt.Sprintf("\n  - %v by %v at %v", entry["hash"], entry["author"], entry["commitTime"]))
+		if msg, ok := entry["message"].(string); ok && msg != "" {
+			sb.WriteString(": " + msg)
+		}
+	}
+	tflog.Warn(ctx, "checkConflict()::Resource changed since it was last read", map[string]any{
+		"lastCommitHash": known,
+		"latestHash":     entries[0]["hash"],
+	})
+	diags.AddAttributeError(path.Root("last_commit_hash"), "Conflicting change to VmwarePluginInstance",
+		fmt.Sprintf("The VmwarePluginInstance %s was changed since Terraform last read it at commit %s. Revisions committed since:%s\n\n"+
+			"Refresh the state and review the plan again to apply the changes on top of these revisions.",
+			tfutils.StringValue(state.Metadata.Name), known, sb.String()))
+	return diags
+}
+
+// Returns the plugin instance as the target of a transaction item
+func vmwarePluginInstanceTarget(data *resource_vmware_plugin_instance.VmwarePluginInstanceModel) apiclient.TransactionTarget {
+	return apiclient.TransactionTarget{
+		Gvk:       gvk_vmwarePluginInstance,
+		Name:      tfutils.StringValue(data.Metadata.Name),
+		Namespace: tfutils.StringValue(data.Metadata.Namespace),
+	}
+}
+
+// Returns body with the apiVersion and kind that transactions require, if not set
+func withTypeMeta_vmwarePluginInstance(body map[string]any) map[string]any {
+	if _, ok := body["apiVersion"]; !ok {
+		body["apiVersion"] = gvk_vmwarePluginInstance.Group + "/" + gvk_vmwarePluginInstance.Version
+	}
+	if _, ok := body["kind"]; !ok {
+		body["kind"] = gvk_vmwarePluginInstance.Kind
+	}
+	return body
+}
+
+// Returns the name of the plugin instance for diagnostics, qualified with its namespace if set
+func vmwarePluginInstanceName(data *resource_vmware_plugin_instance.VmwarePluginInstanceModel) string {
+	name := tfutils.StringValue(data.Metadata.Name)
+	if namespace := tfutils.StringValue(data.Metadata.Namespace); namespace != "" {
+		name = namespace + "/" + name
+	}
+	return "VmwarePluginInstance " + name
+}
+
+// Returns the API path and path parameters addressing the plugin instance, in its namespace if set
+func vmwarePluginInstancePath(pathUrl string, data *resource_vmware_plugin_instance.VmwarePluginInstanceModel) (string, map[string]string) {
+	pathParams := map[string]string{}
+	if strings.Contains(pathUrl, "{name}") {
+		pathParams["name"] = tfutils.StringValue(data.Metadata.Name)
+	}
+	return apiclient.NamespacedPath(pathUrl, pathParams, tfutils.StringValue(data.Metadata.Namespace))
+}
+
+// Returns a shallow copy of body with only the given top level fields
+func filterFields(body map[string]any, fields []string) map[string]any {
+	filtered := map[string]any{}
+	for _, f := range fields {
+		if v, ok := body[f]; ok {
+			filtered[f] = v
+		}
+	}
+	return filtered
 }
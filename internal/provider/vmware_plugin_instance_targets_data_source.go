@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/eda/apiclient"
+	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/tfutils"
+)
+
+const read_ds_vmwarePluginInstanceTargets = "/apps/vmware.eda.nokia.com/v1/vmwareplugininstances/{name}/_targets"
+
+var (
+	_ datasource.DataSource              = (*vmwarePluginInstanceTargetsDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*vmwarePluginInstanceTargetsDataSource)(nil)
+)
+
+// Attribute types of an IntentTarget
+var intentTargetAttrTypes = map[string]attr.Type{
+	"name":      types.StringType,
+	"namespace": types.StringType,
+}
+
+func NewVmwarePluginInstanceTargetsDataSource() datasource.DataSource {
+	return &vmwarePluginInstanceTargetsDataSource{}
+}
+
+type vmwarePluginInstanceTargetsDataSource struct {
+	client *apiclient.EdaApiClient
+}
+
+type vmwarePluginInstanceTargetsModel struct {
+	Name      types.String `tfsdk:"name"`
+	Namespace types.String `tfsdk:"namespace"`
+	Targets   types.List   `tfsdk:"targets"`
+}
+
+func (d *vmwarePluginInstanceTargetsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vmware_plugin_instance_targets"
+}
+
+func (d *vmwarePluginInstanceTargetsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Targets of the intent of a VmwarePluginInstance",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "name of the VmwarePluginInstance",
+			},
+			"namespace": schema.StringAttribute{
+				Optional:    true,
+				Description: "namespace of the VmwarePluginInstance",
+			},
+			"targets": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "targets the VmwarePluginInstance intent touches",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name":      schema.StringAttribute{Computed: true},
+						"namespace": schema.StringAttribute{Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *vmwarePluginInstanceTargetsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = tfutils.MaskSensitiveFields(ctx, d.client.SensitiveKeys())
+	var data vmwarePluginInstanceTargetsModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Read API call logic
+	tflog.Info(ctx, "Read()::API request", map[string]any{
+		"path": read_ds_vmwarePluginInstanceTargets,
+		"data": tfutils.RedactedDump(ctx, &data),
+	})
+
+	t0 := time.Now()
+	targets := []any{}
+	readPath, pathParams := apiclient.NamespacedPath(read_ds_vmwarePluginInstanceTargets,
+		map[string]string{"name": data.Name.ValueString()}, tfutils.StringValue(data.Namespace))
+	err := d.client.Get(ctx, readPath, pathParams, &targets)
+
+	tflog.Info(ctx, "Read()::API returned", map[string]any{
+		"path":      readPath,
+		"result":    tfutils.RedactedDump(ctx, targets),
+		"timeTaken": time.Since(t0).String(),
+	})
+
+	if err != nil {
+		addApiErrorDiagnostics(&resp.Diagnostics, "Error reading intent targets", err)
+		return
+	}
+
+	// Convert API response to Terraform model
+	data.Targets, err = tfutils.AnyListToList(ctx, types.ObjectType{AttrTypes: intentTargetAttrTypes}, targets)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to build response from API result", err.Error())
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *vmwarePluginInstanceTargetsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = providerClient(req.ProviderData, &resp.Diagnostics, "Data Source")
+}
@@ -0,0 +1,464 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/eda/apiclient"
+	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/eda/rest"
+	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/tfutils"
+)
+
+const read_ds_vmwarePluginInstanceTopology = "/apps/vmware.eda.nokia.com/v1/vmwareplugininstances/{name}/_topology"
+
+var (
+	_ datasource.DataSource              = (*vmwarePluginInstanceTopologyDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*vmwarePluginInstanceTopologyDataSource)(nil)
+)
+
+var (
+	topologyTypes = []string{"children", "parents", "childrenAndParents", "dependentCrs"}
+
+	topologyNodeAttrTypes = map[string]attr.Type{
+		"key":       types.StringType,
+		"name":      types.StringType,
+		"namespace": types.StringType,
+		"cr_name":   types.StringType,
+		"ui_name":   types.StringType,
+		"kind":      types.StringType,
+		"group":     types.StringType,
+		"labels":    types.MapType{ElemType: types.StringType},
+		"states":    types.MapType{ElemType: types.Int64Type},
+	}
+	topologyLinkAttrTypes = map[string]attr.Type{
+		"key":             types.StringType,
+		"name":            types.StringType,
+		"namespace":       types.StringType,
+		"cr_name":         types.StringType,
+		"ui_name":         types.StringType,
+		"kind":            types.StringType,
+		"group_key":       types.StringType,
+		"endpoint_a":      types.StringType,
+		"endpoint_a_node": types.StringType,
+		"endpoint_b":      types.StringType,
+		"endpoint_b_node": types.StringType,
+		"labels":          types.MapType{ElemType: types.StringType},
+		"states":          types.MapType{ElemType: types.Int64Type},
+	}
+	topologyEndpointAttrTypes = map[string]attr.Type{
+		"name":      types.StringType,
+		"namespace": types.StringType,
+		"cr_name":   types.StringType,
+		"ui_name":   types.StringType,
+		"kind":      types.StringType,
+		"node":      types.StringType,
+		"node_key":  types.StringType,
+		"labels":    types.MapType{ElemType: types.StringType},
+		"states":    types.MapType{ElemType: types.Int64Type},
+	}
+)
+
+func NewVmwarePluginInstanceTopologyDataSource() datasource.DataSource {
+	return &vmwarePluginInstanceTopologyDataSource{}
+}
+
+type vmwarePluginInstanceTopologyDataSource struct {
+	client *apiclient.EdaApiClient
+}
+
+type vmwarePluginInstanceTopologyModel struct {
+	Name      types.String `tfsdk:"name"`
+	Namespace types.String `tfsdk:"namespace"`
+	Type      types.String `tfsdk:"type"`
+	Recurse   types.Bool   `tfsdk:"recurse"`
+	Filter    types.String `tfsdk:"filter"`
+	Nodes     types.List   `tfsdk:"nodes"`
+	Links     types.List   `tfsdk:"links"`
+	Endpoints types.List   `tfsdk:"endpoints"`
+}
+
+// Subset of the ResourceTopology returned by the API. Unlike other EDA APIs,
+// the topology uses snake_case field names.
+type resourceTopology struct {
+	Topology struct {
+		Nodes map[string]topoOverlayNode `json:"nodes"`
+		Links map[string]topoOverlayLink `json:"links"`
+	} `json:"topology"`
+}
+
+type topoSchema struct {
+	Kind string `json:"kind"`
+}
+
+type topoOverlayState struct {
+	State int64 `json:"state"`
+}
+
+type topoOverlayNode struct {
+	Key       string                      `json:"key"`
+	Name      string                      `json:"name"`
+	Namespace string                      `json:"namespace"`
+	CrName    string                      `json:"cr_name"`
+	UiName    string                      `json:"ui_name"`
+	Schema    topoSchema                  `json:"schema"`
+	Labels    map[string]string           `json:"labels"`
+	Overlays  map[string]topoOverlayState `json:"overlays"`
+	Grouping  struct {
+		Group string `json:"group"`
+	} `json:"grouping"`
+}
+
+type topoOverlayLink struct {
+	Key       string                      `json:"key"`
+	Name      string                      `json:"name"`
+	Namespace string                      `json:"namespace"`
+	CrName    string                      `json:"cr_name"`
+	UiName    string                      `json:"ui_name"`
+	GroupKey  string                      `json:"group_key"`
+	Schema    topoSchema                  `json:"schema"`
+	Labels    map[string]string           `json:"labels"`
+	Overlays  map[string]topoOverlayState `json:"overlays"`
+	EndpointA *topoOverlayEndpoint        `json:"endpoint_a"`
+	EndpointB *topoOverlayEndpoint        `json:"endpoint_b"`
+}
+
+type topoOverlayEndpoint struct {
+	Name      string                      `json:"name"`
+	Namespace string                      `json:"namespace"`
+	CrName    string                      `json:"cr_name"`
+	UiName    string                      `json:"ui_name"`
+	Node      string                      `json:"node"`
+	NodeKey   string                      `json:"node_key"`
+	Schema    topoSchema                  `json:"schema"`
+	Labels    map[string]string           `json:"labels"`
+	Overlays  map[string]topoOverlayState `json:"overlays"`
+}
+
+// Flattened topology elements, as stored in the Terraform state
+type topologyNode struct {
+	Key       string            `tfsdk:"key"`
+	Name      string            `tfsdk:"name"`
+	Namespace string            `tfsdk:"namespace"`
+	CrName    string            `tfsdk:"cr_name"`
+	UiName    string            `tfsdk:"ui_name"`
+	Kind      string            `tfsdk:"kind"`
+	Group     string            `tfsdk:"group"`
+	Labels    map[string]string `tfsdk:"labels"`
+	States    map[string]int64  `tfsdk:"states"`
+}
+
+type topologyLink struct {
+	Key           string            `tfsdk:"key"`
+	Name          string            `tfsdk:"name"`
+	Namespace     string            `tfsdk:"namespace"`
+	CrName        string            `tfsdk:"cr_name"`
+	UiName        string            `tfsdk:"ui_name"`
+	Kind          string            `tfsdk:"kind"`
+	GroupKey      string            `tfsdk:"group_key"`
+	EndpointA     string            `tfsdk:"endpoint_a"`
+	EndpointANode string            `tfsdk:"endpoint_a_node"`
+	EndpointB     string            `tfsdk:"endpoint_b"`
+	EndpointBNode string            `tfsdk:"endpoint_b_node"`
+	Labels        map[string]string `tfsdk:"labels"`
+	States        map[string]int64  `tfsdk:"states"`
+}
+
+type topologyEndpoint struct {
+	Name      string            `tfsdk:"name"`
+	Namespace string            `tfsdk:"namespace"`
+	CrName    string            `tfsdk:"cr_name"`
+	UiName    string            `tfsdk:"ui_name"`
+	Kind      string            `tfsdk:"kind"`
+	Node      string            `tfsdk:"node"`
+	NodeKey   string            `tfsdk:"node_key"`
+	Labels    map[string]string `tfsdk:"labels"`
+	States    map[string]int64  `tfsdk:"states"`
+}
+
+func (d *vmwarePluginInstanceTopologyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vmware_plugin_instance_topology"
+}
+
+func (d *vmwarePluginInstanceTopologyDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	labels := schema.MapAttribute{Computed: true, ElementType: types.StringType}
+	states := schema.MapAttribute{
+		Computed:    true,
+		ElementType: types.Int64Type,
+		Description: "state of the element in each overlay, by overlay name",
+	}
+	resp.Schema = schema.Schema{
+		Description: "Topology of a VmwarePluginInstance",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "name of the VmwarePluginInstance",
+			},
+			"namespace": schema.StringAttribute{
+				Optional:    true,
+				Description: "namespace of the VmwarePluginInstance",
+			},
+			"type": schema.StringAttribute{
+				Optional:    true,
+				Description: "the type of topology to retrieve, one of children, parents, childrenAndParents (default) or dependentCrs",
+				Validators:  []validator.String{stringvalidator.OneOf(topologyTypes...)},
+			},
+			"recurse": schema.BoolAttribute{
+				Optional:    true,
+				Description: "whether to recursively build the topology to include all layers or just a single layer of the topology (default true)",
+			},
+			"filter": schema.StringAttribute{
+				Optional:    true,
+				Description: "JSON encoded TopoStateFilter applied to the nodes and links of the topology",
+			},
+			"nodes": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "nodes of the topology",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key":       schema.StringAttribute{Computed: true},
+						"name":      schema.StringAttribute{Computed: true},
+						"namespace": schema.StringAttribute{Computed: true},
+						"cr_name":   schema.StringAttribute{Computed: true},
+						"ui_name":   schema.StringAttribute{Computed: true},
+						"kind":      schema.StringAttribute{Computed: true},
+						"group":     schema.StringAttribute{Computed: true},
+						"labels":    labels,
+						"states":    states,
+					},
+				},
+			},
+			"links": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "links of the topology",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key":             schema.StringAttribute{Computed: true},
+						"name":            schema.StringAttribute{Computed: true},
+						"namespace":       schema.StringAttribute{Computed: true},
+						"cr_name":         schema.StringAttribute{Computed: true},
+						"ui_name":         schema.StringAttribute{Computed: true},
+						"kind":            schema.StringAttribute{Computed: true},
+						"group_key":       schema.StringAttribute{Computed: true},
+						"endpoint_a":      schema.StringAttribute{Computed: true},
+						"endpoint_a_node": schema.StringAttribute{Computed: true},
+						"endpoint_b":      schema.StringAttribute{Computed: true},
+						"endpoint_b_node": schema.StringAttribute{Computed: true},
+						"labels":          labels,
+						"states":          states,
+					},
+				},
+			},
+			"endpoints": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "endpoints of the links of the topology",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name":      schema.StringAttribute{Computed: true},
+						"namespace": schema.StringAttribute{Computed: true},
+						"cr_name":   schema.StringAttribute{Computed: true},
+						"ui_name":   schema.StringAttribute{Computed: true},
+						"kind":      schema.StringAttribute{Computed: true},
+						"node":      schema.StringAttribute{Computed: true},
+						"node_key":  schema.StringAttribute{Computed: true},
+						"labels":    labels,
+						"states":    states,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *vmwarePluginInstanceTopologyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = tfutils.MaskSensitiveFields(ctx, d.client.SensitiveKeys())
+	var data vmwarePluginInstanceTopologyModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readPath, pathParams := apiclient.NamespacedPath(read_ds_vmwarePluginInstanceTopology,
+		map[string]string{"name": data.Name.ValueString()}, tfutils.StringValue(data.Namespace))
+
+	// A filter can only be sent in the body of a POST request (K8STopologyRequest),
+	// otherwise the type and recurse arguments are sent as query parameters
+	method := rest.HTTP_GET
+	var body map[string]any
+	queryParams := map[string]string{}
+	if filter := tfutils.StringValue(data.Filter); filter != "" {
+		var filterVal map[string]any
+		if err := json.Unmarshal([]byte(filter), &filterVal); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("filter"), "Invalid topology filter",
+				"The filter must be a JSON encoded TopoStateFilter object: "+err.Error())
+			return
+		}
+		method = rest.HTTP_POST
+		body = map[string]any{"filter": filterVal}
+		if !data.Type.IsNull() {
+			body["type"] = data.Type.ValueString()
+		}
+		if !data.Recurse.IsNull() {
+			body["recurse"] = data.Recurse.ValueBool()
+		}
+	} else {
+		if !data.Type.IsNull() {
+			queryParams["type"] = data.Type.ValueString()
+		}
+		if !data.Recurse.IsNull() {
+			queryParams["recurse"] = strconv.FormatBool(data.Recurse.ValueBool())
+		}
+	}
+
+	// Read API call logic
+	tflog.Info(ctx, "Read()::API request", map[string]any{
+		"path":   readPath,
+		"method": method,
+		"query":  queryParams,
+		"body":   tfutils.RedactedDump(ctx, body),
+	})
+
+	t0 := time.Now()
+	result := resourceTopology{}
+	err := d.client.Execute(ctx, readPath, method, pathParams, queryParams, body, &result)
+
+	tflog.Info(ctx, "Read()::API returned", map[string]any{
+		"path":      readPath,
+		"nodes":     len(result.Topology.Nodes),
+		"links":     len(result.Topology.Links),
+		"timeTaken": time.Since(t0).String(),
+	})
+
+	if err != nil {
+		addApiErrorDiagnostics(&resp.Diagnostics, "Error reading topology", err)
+		return
+	}
+
+	// Flatten the API response into the Terraform model
+	nodes, links, endpoints := flattenTopology(&result)
+	var diags diag.Diagnostics
+	data.Nodes, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: topologyNodeAttrTypes}, nodes)
+	resp.Diagnostics.Append(diags...)
+	data.Links, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: topologyLinkAttrTypes}, links)
+	resp.Diagnostics.Append(diags...)
+	data.Endpoints, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: topologyEndpointAttrTypes}, endpoints)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Flattens the topology into lists of nodes, links and link endpoints, sorted by
+// key (nodes, links) or node key, namespace and name (endpoints) for a stable order.
+// Endpoints shared by several links are listed once.
+func flattenTopology(topo *resourceTopology) ([]topologyNode, []topologyLink, []topologyEndpoint) {
+	nodes := make([]topologyNode, 0, len(topo.Topology.Nodes))
+	for _, key := range sortedMapKeys(topo.Topology.Nodes) {
+		n := topo.Topology.Nodes[key]
+		nodes = append(nodes, topologyNode{
+			Key:       key,
+			Name:      n.Name,
+			Namespace: n.Namespace,
+			CrName:    n.CrName,
+			UiName:    n.UiName,
+			Kind:      n.Schema.Kind,
+			Group:     n.Grouping.Group,
+			Labels:    n.Labels,
+			States:    overlayStates(n.Overlays),
+		})
+	}
+
+	links := make([]topologyLink, 0, len(topo.Topology.Links))
+	endpoints := map[string]topologyEndpoint{}
+	for _, key := range sortedMapKeys(topo.Topology.Links) {
+		l := topo.Topology.Links[key]
+		link := topologyLink{
+			Key:       key,
+			Name:      l.Name,
+			Namespace: l.Namespace,
+			CrName:    l.CrName,
+			UiName:    l.UiName,
+			Kind:      l.Schema.Kind,
+			GroupKey:  l.GroupKey,
+			Labels:    l.Labels,
+			States:    overlayStates(l.Overlays),
+		}
+		if ep := l.EndpointA; ep != nil {
+			link.EndpointA, link.EndpointANode = ep.Name, ep.Node
+			endpoints[endpointKey(ep)] = flattenEndpoint(ep)
+		}
+		if ep := l.EndpointB; ep != nil {
+			link.EndpointB, link.EndpointBNode = ep.Name, ep.Node
+			endpoints[endpointKey(ep)] = flattenEndpoint(ep)
+		}
+		links = append(links, link)
+	}
+
+	endpointList := make([]topologyEndpoint, 0, len(endpoints))
+	for _, key := range sortedMapKeys(endpoints) {
+		endpointList = append(endpointList, endpoints[key])
+	}
+	return nodes, links, endpointList
+}
+
+// Returns the key identifying an endpoint in the topology. Endpoints are named after
+// the interface, so endpoints of different nodes can have the same namespace and name.
+func endpointKey(ep *topoOverlayEndpoint) string {
+	return ep.NodeKey + "\x00" + ep.Namespace + "\x00" + ep.Name
+}
+
+func flattenEndpoint(ep *topoOverlayEndpoint) topologyEndpoint {
+	return topologyEndpoint{
+		Name:      ep.Name,
+		Namespace: ep.Namespace,
+		CrName:    ep.CrName,
+		UiName:    ep.UiName,
+		Kind:      ep.Schema.Kind,
+		Node:      ep.Node,
+		NodeKey:   ep.NodeKey,
+		Labels:    ep.Labels,
+		States:    overlayStates(ep.Overlays),
+	}
+}
+
+func overlayStates(overlays map[string]topoOverlayState) map[string]int64 {
+	if overlays == nil {
+		return nil
+	}
+	states := make(map[string]int64, len(overlays))
+	for name, overlay := range overlays {
+		states[name] = overlay.State
+	}
+	return states
+}
+
+func sortedMapKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *vmwarePluginInstanceTopologyDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = providerClient(req.ProviderData, &resp.Diagnostics, "Data Source")
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// Topology of two links between the interfaces of two nodes. Interface endpoints are
+// named after the interface, so both nodes have an endpoint named ethernet-1-1.
+const testTopology = `{
+  "topology": {
+    "nodes": {
+      "leaf1": {"name": "leaf1", "namespace": "eda", "schema": {"kind": "TopoNode"}, "grouping": {"group": "leaf"},
+        "overlays": {"alarms": {"state": 2}}},
+      "leaf2": {"name": "leaf2", "namespace": "eda", "schema": {"kind": "TopoNode"}, "labels": {"role": "leaf"}}
+    },
+    "links": {
+      "leaf1-leaf2-1": {"name": "leaf1-leaf2-1", "namespace": "eda", "schema": {"kind": "TopoLink"},
+        "endpoint_a": {"name": "ethernet-1-1", "namespace": "eda", "node": "leaf1", "node_key": "leaf1", "schema": {"kind": "Interface"}},
+        "endpoint_b": {"name": "ethernet-1-1", "namespace": "eda", "node": "leaf2", "node_key": "leaf2", "schema": {"kind": "Interface"}}},
+      "leaf1-leaf2-2": {"name": "leaf1-leaf2-2", "namespace": "eda", "schema": {"kind": "TopoLink"},
+        "endpoint_a": {"name": "ethernet-1-1", "namespace": "eda", "node": "leaf1", "node_key": "leaf1", "schema": {"kind": "Interface"}},
+        "endpoint_b": {"name": "ethernet-1-2", "namespace": "eda", "node": "leaf2", "node_key": "leaf2", "schema": {"kind": "Interface"}}}
+    }
+  }
+}`
+
+func TestFlattenTopology(t *testing.T) {
+	var topo resourceTopology
+	if err := json.Unmarshal([]byte(testTopology), &topo); err != nil {
+		t.Fatal(err)
+	}
+	nodes, links, endpoints := flattenTopology(&topo)
+
+	expectedNodes := []topologyNode{
+		{Key: "leaf1", Name: "leaf1", Namespace: "eda", Kind: "TopoNode", Group: "leaf", States: map[string]int64{"alarms": 2}},
+		{Key: "leaf2", Name: "leaf2", Namespace: "eda", Kind: "TopoNode", Labels: map[string]string{"role": "leaf"}},
+	}
+	if !reflect.DeepEqual(nodes, expectedNodes) {
+		t.Errorf("nodes = %+v, want %+v", nodes, expectedNodes)
+	}
+
+	expectedLinks := []topologyLink{
+		{Key: "leaf1-leaf2-1", Name: "leaf1-leaf2-1", Namespace: "eda", Kind: "TopoLink",
+			EndpointA: "ethernet-1-1", EndpointANode: "leaf1", EndpointB: "ethernet-1-1", EndpointBNode: "leaf2"},
+		{Key: "leaf1-leaf2-2", Name: "leaf1-leaf2-2", Namespace: "eda", Kind: "TopoLink",
+			EndpointA: "ethernet-1-1", EndpointANode: "leaf1", EndpointB: "ethernet-1-2", EndpointBNode: "leaf2"},
+	}
+	if !reflect.DeepEqual(links, expectedLinks) {
+		t.Errorf("links = %+v, want %+v", links, expectedLinks)
+	}
+
+	// The endpoint of leaf1 shared by both links is listed once, and the endpoints
+	// of both nodes named ethernet-1-1 are kept
+	expectedEndpoints := []topologyEndpoint{
+		{Name: "ethernet-1-1", Namespace: "eda", Kind: "Interface", Node: "leaf1", NodeKey: "leaf1"},
+		{Name: "ethernet-1-1", Namespace: "eda", Kind: "Interface", Node: "leaf2", NodeKey: "leaf2"},
+		{Name: "ethernet-1-2", Namespace: "eda", Kind: "Interface", Node: "leaf2", NodeKey: "leaf2"},
+	}
+	if !reflect.DeepEqual(endpoints, expectedEndpoints) {
+		t.Errorf("endpoints = %+v, want %+v", endpoints, expectedEndpoints)
+	}
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/eda/apiclient"
+)
+
+// Adds the detail_level and disable_batching attributes, overriding the provider
+// defaults for the write requests of a resource, to the resource schema
+func addWriteOptionsAttributes(attributes map[string]schema.Attribute) {
+	attributes["detail_level"] = schema.StringAttribute{
+		Optional: true,
+		Description: "detail level kept in the transaction log for the transactions of the write requests of this resource, " +
+			"'standard' or 'detailed', overrides the provider setting. With 'detailed', the transaction of each write is reported as a warning",
+		Validators: []validator.String{
+			stringvalidator.OneOf(apiclient.DETAIL_LEVEL_STANDARD, apiclient.DETAIL_LEVEL_DETAILED),
+		},
+	}
+	attributes["disable_batching"] = schema.BoolAttribute{
+		Optional:    true,
+		Description: "prevent the transactions of the write requests of this resource from being bundled with others, overrides the provider setting",
+	}
+}
+
+// Returns the write options of a resource, the provider defaults overridden by the resource attributes when set
+func writeOptions(client *apiclient.EdaApiClient, detailLevel types.String, disableBatching types.Bool) apiclient.WriteOptions {
+	opts := client.DefaultWriteOptions()
+	if !detailLevel.IsNull() && !detailLevel.IsUnknown() {
+		opts.DetailLevel = detailLevel.ValueString()
+	}
+	if !disableBatching.IsNull() && !disableBatching.IsUnknown() {
+		opts.DisableBatching = disableBatching.ValueBool()
+	}
+	return opts
+}
+
+// Returns errors for the write options set in the resource configuration. Transactions
+// have no write options, so they can not be used in transaction mode.
+func validateTransactionWriteOptions(ctx context.Context, config tfsdk.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var detailLevel types.String
+	var disableBatching types.Bool
+	diags.Append(config.GetAttribute(ctx, path.Root("detail_level"), &detailLevel)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("disable_batching"), &disableBatching)...)
+	if diags.HasError() {
+		return diags
+	}
+	if !detailLevel.IsNull() {
+		diags.AddAttributeError(path.Root("detail_level"), "Write Option Not Supported In Transaction Mode",
+			"Writes are committed in transactions in transaction mode, which do not support the detail level. "+
+				"Unset detail_level, or disable transaction_mode in the provider configuration.")
+	}
+	if !disableBatching.IsNull() {
+		diags.AddAttributeError(path.Root("disable_batching"), "Write Option Not Supported In Transaction Mode",
+			"Writes are committed in transactions in transaction mode, which do not support disabling batching. "+
+				"Unset disable_batching, or disable transaction_mode in the provider configuration.")
+	}
+	return diags
+}
+
+// Reports the transaction that committed a write made with the 'detailed' detail level.
+// Write responses return the written resource, or a Status for deletes, without any
+// transaction metadata, so the transaction is read from the revision of the resource
+// recorded by the write: a ResourceHistoryEntry, or a DeletedResourceEntry for deletes.
+// Nothing is reported if the revision could not be read.
+func addWriteTransactionDiagnostics(ctx context.Context, diags *diag.Diagnostics, summary string, revision map[string]any) {
+	if revision == nil {
+		tflog.Warn(ctx, "Write transaction not found", map[string]any{"write": summary})
+		return
+	}
+	tflog.Info(ctx, "Write transaction", map[string]any{
+		"write":         summary,
+		"transactionId": revision["transactionId"],
+		"changeType":    revision["changeType"],
+		"hash":          revision["hash"],
+		"commitTime":    revision["commitTime"],
+		"author":        revision["author"],
+		"message":       revision["message"],
+	})
+
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("Transaction: %v\nCommit: %v at %v", revision["transactionId"], revision["hash"], revision["commitTime"]))
+	for _, field := range []string{"changeType", "author", "message"} {
+		if v, ok := revision[field].(string); ok && v != "" {
+			sb.WriteString(fmt.Sprintf("\n%s: %s", field, v))
+		}
+	}
+	diags.AddWarning(fmt.Sprintf("%s in transaction %v", summary, revision["transactionId"]), sb.String())
+}
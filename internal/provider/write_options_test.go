@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestAddWriteTransactionDiagnostics(t *testing.T) {
+	var diags diag.Diagnostics
+	addWriteTransactionDiagnostics(context.Background(), &diags, "Updated VmwarePluginInstance vc1", map[string]any{
+		"transactionId": float64(42),
+		"hash":          "abc123",
+		"commitTime":    "2026-01-02T03:04:05Z",
+		"changeType":    "Modified",
+		"author":        "admin",
+	})
+	if len(diags) != 1 || diags.WarningsCount() != 1 {
+		t.Fatalf("diagnostics = %v, want a single warning", diags)
+	}
+	if summary, want := diags[0].Summary(), "Updated VmwarePluginInstance vc1 in transaction 42"; summary != want {
+		t.Errorf("summary = %q, want %q", summary, want)
+	}
+	for _, want := range []string{"Transaction: 42", "Commit: abc123 at 2026-01-02T03:04:05Z", "changeType: Modified", "author: admin"} {
+		if !strings.Contains(diags[0].Detail(), want) {
+			t.Errorf("detail = %q, want it to contain %q", diags[0].Detail(), want)
+		}
+	}
+	if strings.Contains(diags[0].Detail(), "message") {
+		t.Errorf("detail = %q, want no empty message", diags[0].Detail())
+	}
+
+	diags = nil
+	addWriteTransactionDiagnostics(context.Background(), &diags, "Created VmwarePluginInstance vc1", nil)
+	if len(diags) != 0 {
+		t.Errorf("diagnostics = %v, want none without a revision", diags)
+	}
+}
+
+func TestLastDeletedEntry(t *testing.T) {
+	entries := []map[string]any{
+		{"name": "vc1", "namespace": "eda", "transactionId": float64(1)},
+		{"name": "vc2", "namespace": "eda", "transactionId": float64(2)},
+		{"name": "vc1", "namespace": "lab", "transactionId": float64(3)},
+		{"name": "vc1", "namespace": "eda", "transactionId": float64(4)},
+	}
+	tests := []struct {
+		name      string
+		namespace string
+		expected  any
+	}{
+		{name: "vc1", namespace: "eda", expected: float64(4)},
+		{name: "vc1", namespace: "lab", expected: float64(3)},
+		{name: "vc2", namespace: "", expected: float64(2)},
+		{name: "vc3", namespace: "eda", expected: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.namespace+"/"+tt.name, func(t *testing.T) {
+			var got any
+			if entry := lastDeletedEntry(entries, tt.name, tt.namespace); entry != nil {
+				got = entry["transactionId"]
+			}
+			if got != tt.expected {
+				t.Errorf("lastDeletedEntry(%q, %q) = transaction %v, want %v", tt.name, tt.namespace, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package tfutils
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/nokia/eda/apps/terraform-provider-vmware/internal/eda/utils"
+)
+
+// MaskSensitiveFields returns a context that masks the values of the sensitive log fields
+// of keys, and carries keys for RedactedDump. A nil keys keeps the keys carried by ctx.
+func MaskSensitiveFields(ctx context.Context, keys *utils.SensitiveKeySet) context.Context {
+	if keys == nil {
+		keys = utils.SensitiveKeys(ctx)
+	}
+	ctx = utils.WithSensitiveKeys(ctx, keys)
+	return tflog.MaskFieldValuesWithFieldKeys(ctx, keys.Keys()...)
+}
+
+// RedactedDump returns a dump of a Terraform model, or of data exchanged with the API,
+// for logging. The values of the sensitive keys carried by ctx are redacted.
+func RedactedDump(ctx context.Context, v any) string {
+	keys := utils.SensitiveKeys(ctx)
+	switch v.(type) {
+	case map[string]any, map[string]string, []map[string]any, []any:
+		return spew.Sdump(keys.Redact(v))
+	}
+	val := reflect.ValueOf(v)
+	if val.Kind() == reflect.Ptr && !val.IsNil() && val.Elem().Kind() == reflect.Struct {
+		body := map[string]any{}
+		if err := modelToRedactedMap(ctx, val.Elem(), body); err == nil {
+			return spew.Sdump(body)
+		}
+	}
+	// Fall back to a round trip through JSON, so that nothing is dumped unredacted
+	data, err := json.Marshal(v)
+	if err != nil {
+		return utils.REDACTED
+	}
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return utils.REDACTED
+	}
+	return spew.Sdump(keys.Redact(generic))
+}
+
+// Adds the attr.Value fields of a model struct to body, including those of embedded models
+func modelToRedactedMap(ctx context.Context, model reflect.Value, body map[string]any) error {
+	attrValIf := reflect.TypeOf((*attr.Value)(nil)).Elem()
+	keys := utils.SensitiveKeys(ctx)
+	for i := range model.NumField() {
+		field := model.Type().Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := modelToRedactedMap(ctx, model.Field(i), body); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.Type.Implements(attrValIf) {
+			continue
+		}
+		name := field.Tag.Get("tfsdk")
+		attrVal := model.Field(i).Interface().(attr.Value)
+		switch {
+		case attrVal.IsNull():
+			body[name] = nil
+		case attrVal.IsUnknown():
+			body[name] = "<unknown>"
+		case keys.IsSensitive(name):
+			body[name] = utils.REDACTED
+		default:
+			anyVal, err := fromValue(ctx, attrVal, "")
+			if err != nil {
+				return err
+			}
+			body[name] = keys.Redact(anyVal)
+		}
+	}
+	return nil
+}
+
+// Returns the string form of an attr.Value for logging, with sensitive values redacted
+func redactedAttrString(ctx context.Context, name string, attrVal attr.Value) string {
+	keys := utils.SensitiveKeys(ctx)
+	if keys.IsSensitive(name) && !attrVal.IsNull() && !attrVal.IsUnknown() {
+		return utils.REDACTED
+	}
+	return keys.RedactString(attrVal.String())
+}
+
+func redactedAttrMap(ctx context.Context, values map[string]attr.Value) map[string]string {
+	redacted := make(map[string]string, len(values))
+	for k, v := range values {
+		redacted[k] = redactedAttrString(ctx, k, v)
+	}
+	return redacted
+}
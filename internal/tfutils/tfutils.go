@@ -28,6 +28,7 @@ var (
 	snakeToCamelNames = map[string]string{
 		"external_id":     "externalId",
 		"labelselector":   "label-selector",
+		"transaction_id":  "transactionId",
 		"vcsa_tls_verify": "vcsaTlsVerify",
 	}
 	camelToSnakeNames = map[string]string{}
@@ -381,7 +382,7 @@ func newValue(ctx context.Context, attrTypeIf attr.Type, val any, visitId string
 			return nil, fmt.Errorf("expected map[string]any, got %T", val)
 		}
 		tflog.Trace(ctx, "newValue()::MapType case",
-			map[string]any{"valuesMap": spew.Sdump(valuesMap), "visitId": visitId})
+			map[string]any{"valuesMap": RedactedDump(ctx, valuesMap), "visitId": visitId})
 
 		newValMap := make(map[string]attr.Value)
 		oldVisitId := visitId
@@ -411,11 +412,11 @@ func newValue(ctx context.Context, attrTypeIf attr.Type, val any, visitId string
 			}
 		}
 		tflog.Trace(ctx, "newValue()::MapType case: Constructing MapValue",
-			map[string]any{"newValMap": spew.Sdump(newValMap), "visitId": visitId})
+			map[string]any{"newValMap": spew.Sdump(redactedAttrMap(ctx, newValMap)), "visitId": visitId})
 
 		mapVal, d := types.MapValue(attrType.ElemType, newValMap)
 		if d.HasError() {
-			return nil, fmt.Errorf("failed to create map value from: %s, diag: %v", spew.Sdump(newValMap), d)
+			return nil, fmt.Errorf("failed to create map value from: %s, diag: %v", spew.Sdump(redactedAttrMap(ctx, newValMap)), d)
 		}
 		return mapVal, nil
 	case basetypes.NumberType:
@@ -436,7 +437,7 @@ func newValue(ctx context.Context, attrTypeIf attr.Type, val any, visitId string
 			return nil, fmt.Errorf("expected map[string]any, got %T", val)
 		}
 		tflog.Trace(ctx, "newValue()::ObjectType case",
-			map[string]any{"valuesMap": spew.Sdump(valuesMap), "visitId": visitId})
+			map[string]any{"valuesMap": RedactedDump(ctx, valuesMap), "visitId": visitId})
 
 		newValMap := make(map[string]attr.Value)
 		oldVisitId := visitId
@@ -463,11 +464,11 @@ func newValue(ctx context.Context, attrTypeIf attr.Type, val any, visitId string
 			}
 		}
 		tflog.Trace(ctx, "newValue()::ObjectType case: Constructing ObjectValue",
-			map[string]any{"newValMap": spew.Sdump(newValMap), "visitId": visitId})
+			map[string]any{"newValMap": spew.Sdump(redactedAttrMap(ctx, newValMap)), "visitId": visitId})
 
 		objVal, d := types.ObjectValue(attrType.AttributeTypes(), newValMap)
 		if d.HasError() {
-			return nil, fmt.Errorf("failed to create value from obj using map: %s, diag: %v", spew.Sdump(newValMap), d)
+			return nil, fmt.Errorf("failed to create value from obj using map: %s, diag: %v", spew.Sdump(redactedAttrMap(ctx, newValMap)), d)
 		}
 		return objVal, nil
 	case basetypes.SetType:
@@ -513,7 +514,7 @@ func newValue(ctx context.Context, attrTypeIf attr.Type, val any, visitId string
 			return nil, fmt.Errorf("expected map[string]any, got %T", val)
 		}
 		tflog.Trace(ctx, "newValue()::ObjectTypable case",
-			map[string]any{"valuesMap": spew.Sdump(valuesMap), "visitId": visitId})
+			map[string]any{"valuesMap": RedactedDump(ctx, valuesMap), "visitId": visitId})
 
 		newValMap := make(map[string]attr.Value)
 		oldVisitId := visitId
@@ -539,15 +540,15 @@ func newValue(ctx context.Context, attrTypeIf attr.Type, val any, visitId string
 			}
 		}
 		tflog.Trace(ctx, "newValue()::ObjectTypable case: Constructing ObjectValue",
-			map[string]any{"newValMap": spew.Sdump(newValMap), "visitId": visitId})
+			map[string]any{"newValMap": spew.Sdump(redactedAttrMap(ctx, newValMap)), "visitId": visitId})
 
 		newObjVal, d := types.ObjectValue(objVal.AttributeTypes(ctx), newValMap)
 		if d.HasError() {
-			return nil, fmt.Errorf("failed to create new obj from value using map: %s, diag: %v", spew.Sdump(newValMap), d)
+			return nil, fmt.Errorf("failed to create new obj from value using map: %s, diag: %v", spew.Sdump(redactedAttrMap(ctx, newValMap)), d)
 		}
 		newValue, d := attrType.ValueFromObject(ctx, newObjVal)
 		if d.HasError() {
-			return nil, fmt.Errorf("failed to create new value from obj: %s, diag: %v", spew.Sdump(newObjVal), d)
+			return nil, fmt.Errorf("failed to create new value from obj: %s, diag: %v", redactedAttrString(ctx, "", newObjVal), d)
 		}
 		return newValue, nil
 	default:
@@ -618,7 +619,7 @@ func fromValue(ctx context.Context, attrValIf attr.Value, visitId string) (any,
 			}
 		}
 		tflog.Trace(ctx, "fromValue()::Returning map from MapValue case",
-			map[string]any{"values": spew.Sdump(value), "visitId": visitId})
+			map[string]any{"values": RedactedDump(ctx, value), "visitId": visitId})
 		return value, nil
 	case basetypes.NumberValue:
 		if attrVal.ValueBigFloat().IsInt() {
@@ -660,7 +661,7 @@ func fromValue(ctx context.Context, attrValIf attr.Value, visitId string) (any,
 			}
 		}
 		tflog.Trace(ctx, "fromValue()::Returning map from ObjectValue case",
-			map[string]any{"values": spew.Sdump(value), "visitId": visitId})
+			map[string]any{"values": RedactedDump(ctx, value), "visitId": visitId})
 		return value, nil
 	case basetypes.SetValue:
 		value := []any{}
@@ -806,7 +807,7 @@ func FillMissingValues(ctx context.Context, model any) error {
 			"fieldType": field.Type.String(),
 			"fieldKind": field.Type.Kind().String(),
 			"isUnknown": attrVal.IsUnknown(),
-			"attrVal":   attrVal.String(),
+			"attrVal":   redactedAttrString(ctx, field.Tag.Get("tfsdk"), attrVal),
 		})
 
 		if attrVal.IsUnknown() {
@@ -822,7 +823,7 @@ func FillMissingValues(ctx context.Context, model any) error {
 			switch attrVal.Type(ctx).(type) {
 			case basetypes.ObjectTypable:
 				tflog.Trace(ctx, "FillMissingValues()::ObjectTypable case",
-					map[string]any{"fieldName": field.Name, "attrVal": attrVal.String()})
+					map[string]any{"fieldName": field.Name, "attrVal": redactedAttrString(ctx, field.Tag.Get("tfsdk"), attrVal)})
 				objVal, err := fillObjectNull(ctx, attrVal.(basetypes.ObjectValuable))
 				if err != nil {
 					return err
@@ -897,7 +898,7 @@ func ModelToStringMap(ctx context.Context, model any) (map[string]string, error)
 			"fieldType": field.Type.String(),
 			"fieldKind": field.Type.Kind().String(),
 			"isUnknown": attrVal.IsUnknown(),
-			"attrVal":   attrVal.String(),
+			"attrVal":   redactedAttrString(ctx, fieldName, attrVal),
 		})
 
 		// If the attr.Value is not null and not unknown, and is a string type, use it to build the map
@@ -943,7 +944,7 @@ func ModelToAnyMap(ctx context.Context, model any) (map[string]any, error) {
 			"fieldType": field.Type.String(),
 			"fieldKind": field.Type.Kind().String(),
 			"isUnknown": attrVal.IsUnknown(),
-			"attrVal":   attrVal.String(),
+			"attrVal":   redactedAttrString(ctx, fieldName, attrVal),
 		})
 
 		// If the attr.Value is not null and not unknown, use it to build the request
@@ -959,6 +960,73 @@ func ModelToAnyMap(ctx context.Context, model any) (map[string]any, error) {
 	return body, nil
 }
 
+// AnyListToList converts a list decoded from an API response to a types.List
+// with elements of elemType, mapping object attributes like AnyMapToModel.
+func AnyListToList(ctx context.Context, elemType attr.Type, values []any) (types.List, error) {
+	listVal, err := newValue(ctx, types.ListType{ElemType: elemType}, values, "")
+	if err != nil {
+		return types.ListNull(elemType), err
+	}
+	return listVal.(types.List), nil
+}
+
+// Takes a context and a pointer to any model, and returns the JSON pointer paths
+// (using the API field names) of all the unknown values in the model, including
+// those nested inside object values.
+func UnknownPaths(ctx context.Context, model any) ([]string, error) {
+	typ := reflect.TypeOf(model)
+	val := reflect.ValueOf(model)
+
+	// Check if the type is a pointer to a struct
+	if typ.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("expected pointer to struct, got %s", typ.Kind())
+	}
+
+	paths := []string{}
+	attrValIf := reflect.TypeOf((*attr.Value)(nil)).Elem()
+	for i := range typ.Elem().NumField() {
+		field := typ.Elem().Field(i)
+		// Check if the model struct field implements attr.Value
+		if !field.Type.Implements(attrValIf) {
+			continue
+		}
+		attrVal := val.Elem().Field(i).Interface().(attr.Value)
+		fieldPaths, err := unknownPaths(ctx, "/"+SnakeToCamel(field.Tag.Get("tfsdk")), attrVal)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, fieldPaths...)
+	}
+	tflog.Debug(ctx, "UnknownPaths()", map[string]any{"paths": paths})
+	return paths, nil
+}
+
+func unknownPaths(ctx context.Context, prefix string, attrValIf attr.Value) ([]string, error) {
+	if attrValIf == nil || attrValIf.IsNull() {
+		return nil, nil
+	}
+	if attrValIf.IsUnknown() {
+		return []string{prefix}, nil
+	}
+	objValIf, ok := attrValIf.(basetypes.ObjectValuable)
+	if !ok {
+		return nil, nil
+	}
+	objVal, d := objValIf.ToObjectValue(ctx)
+	if d.HasError() {
+		return nil, fmt.Errorf("failed to get obj value: %v", d)
+	}
+	paths := []string{}
+	for name, atVal := range objVal.Attributes() {
+		attrPaths, err := unknownPaths(ctx, prefix+"/"+SnakeToCamel(name), atVal)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, attrPaths...)
+	}
+	return paths, nil
+}
+
 func AnyMapToModel(ctx context.Context, resp map[string]any, model any) error {
 	modelType := reflect.TypeOf(model)
 	modelValue := reflect.ValueOf(model)
@@ -990,7 +1058,7 @@ func AnyMapToModel(ctx context.Context, resp map[string]any, model any) error {
 			"fieldType": field.Type.String(),
 			"fieldKind": field.Type.Kind().String(),
 			"isUnknown": attrVal.IsUnknown(),
-			"attrVal":   attrVal.String(),
+			"attrVal":   redactedAttrString(ctx, fieldName, attrVal),
 		})
 
 		newVal, err := newValue(ctx, attrVal.Type(ctx), resp[fieldName], "")